@@ -1,14 +1,15 @@
 package account
 
 import (
-	"fmt"
 	"crypto/rand"
+	"fmt"
+
 	"github.com/iost-official/Go-IOS-Protocol/common"
 )
 
 var (
 	MainAccount    Account
-	GenesisAccount = map[string]float64{
+	GenesisAccount = map[string]int64{
 		"2BibFrAhc57FAd3sDJFbPqjwskBJb5zPDtecPWVRJ1jxT": 3400000000,
 		"tUFikMypfNGxuJcNbfreh8LM893kAQVNTktVQRsFYuEU":  3200000000,
 		"s1oUQNTcRKL7uqJ1aRqUMzkAkgqJdsBB7uW9xrTd85qB":  3100000000,
@@ -18,7 +19,7 @@ var (
 		"28mKnLHaVvc1YRKc9CWpZxCpo2gLVCY3RL5nC9WbARRym": 2600000000,
 	}
 	// local net
-	//GenesisAccount = map[string]float64{
+	//GenesisAccount = map[string]int64{
 	//	"iWgLQj3VTPN4dZnomuJMMCggv22LFw4nAkA6bmrVsmCo":  13400000000,
 	//	"281pWKbjMYGWKf2QHXUKDy4rVULbF61WGCZoi4PiKhbEk": 13200000000,
 	//	"bj38rN9xdqBa4eiMi1vPjcUwdMyZmQhvYbVA6cnHyQCH":  13100000000,
@@ -26,12 +27,20 @@ var (
 )
 
 type Account struct {
-	ID     string
-	Pubkey []byte
-	Seckey []byte
+	ID        string
+	Pubkey    []byte
+	Seckey    []byte
+	Algorithm common.SignAlgorithm
 }
 
+// NewAccount makes a secp256k1 account of seckey, or of a random seckey if it is nil
 func NewAccount(seckey []byte) (Account, error) {
+	return NewAccountWithAlgorithm(seckey, common.Secp256k1)
+}
+
+// NewAccountWithAlgorithm makes an account signing with algo. The public key, and so
+// the ID, of an account that does not use secp256k1 is led by a tag of its algorithm.
+func NewAccountWithAlgorithm(seckey []byte, algo common.SignAlgorithm) (Account, error) {
 	var m Account
 	if seckey == nil {
 		seckey = randomSeckey()
@@ -40,12 +49,39 @@ func NewAccount(seckey []byte) (Account, error) {
 		return Account{}, fmt.Errorf("seckey length error")
 	}
 
+	pubkey, err := common.CalcPubkey(algo, seckey)
+	if err != nil {
+		return Account{}, err
+	}
 	m.Seckey = seckey
-	m.Pubkey = makePubkey(seckey)
+	m.Pubkey = pubkey
 	m.ID = GetIdByPubkey(m.Pubkey)
+	m.Algorithm = algo
 	return m, nil
 }
 
+// Sign signs info with the seckey and the algorithm of the account
+func (member *Account) Sign(info []byte) (common.Signature, error) {
+	return common.Sign(member.Algorithm, info, member.Seckey)
+}
+
+// ExportSeckey returns the seckey in the form read by ImportAccount, the seckey of an
+// account that does not use secp256k1 is led by its algorithm
+func (member *Account) ExportSeckey() []byte {
+	if member.Algorithm == common.Secp256k1 {
+		return member.Seckey
+	}
+	return append([]byte{byte(member.Algorithm)}, member.Seckey...)
+}
+
+// ImportAccount makes the account of a seckey returned by ExportSeckey
+func ImportAccount(exported []byte) (Account, error) {
+	if len(exported) == 33 {
+		return NewAccountWithAlgorithm(exported[1:], common.SignAlgorithm(exported[0]))
+	}
+	return NewAccount(exported)
+}
+
 func (member *Account) GetId() string {
 	return member.ID
 }
@@ -56,10 +92,6 @@ func randomSeckey() []byte {
 	return seckey
 }
 
-func makePubkey(seckey []byte) []byte {
-	return common.CalcPubkeyInSecp256k1(seckey)
-}
-
 func GetIdByPubkey(pubkey []byte) string {
 	return common.Base58Encode(pubkey)
 }
@@ -67,3 +99,9 @@ func GetIdByPubkey(pubkey []byte) string {
 func GetPubkeyByID(ID string) []byte {
 	return common.Base58Decode(ID)
 }
+
+// GetAlgorithmByID returns the signature algorithm of the account ID
+func GetAlgorithmByID(ID string) (common.SignAlgorithm, error) {
+	algo, _, err := common.PubkeyAlgorithm(GetPubkeyByID(ID))
+	return algo, err
+}
@@ -32,5 +32,30 @@ func TestMember(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(Base58Encode(m.Pubkey), ShouldEqual, "iWgLQj3VTPN4dZnomuJMMCggv22LFw4nAkA6bmrVsmCo")
 		})
+		Convey("other algorithms", func() {
+			seckey := Base58Decode("3BZ3HWs2nWucCCvLp7FRFv1K7RR3fAjjEQccf9EJrTv4")
+			ids := map[string]SignAlgorithm{}
+			for _, algo := range []SignAlgorithm{Secp256k1, Ed25519, Secp256r1} {
+				m, err := NewAccountWithAlgorithm(seckey, algo)
+				So(err, ShouldBeNil)
+				So(m.Algorithm, ShouldEqual, algo)
+				ids[m.ID] = algo
+
+				id, err := GetAlgorithmByID(m.ID)
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, algo)
+
+				sig, err := m.Sign(Sha256([]byte("hello")))
+				So(err, ShouldBeNil)
+				So(bytes.Equal(sig.Pubkey, m.Pubkey), ShouldBeTrue)
+				So(VerifySignature(Sha256([]byte("hello")), sig), ShouldBeTrue)
+
+				m2, err := ImportAccount(m.ExportSeckey())
+				So(err, ShouldBeNil)
+				So(m2.ID, ShouldEqual, m.ID)
+				So(m2.Algorithm, ShouldEqual, algo)
+			}
+			So(len(ids), ShouldEqual, 3)
+		})
 	})
 }
@@ -1,8 +1,14 @@
 package common
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"math/big"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
@@ -58,3 +64,79 @@ func CalcPubkeyInSecp256k1(privkey []byte) []byte {
 	x, y := myCurve.ScalarBaseMult(privkey)
 	return secp256k1.CompressPubkey(x, y)
 }
+
+func SignInEd25519(info, seed []byte) []byte {
+	if len(seed) != ed25519.SeedSize {
+		return nil
+	}
+	return ed25519.Sign(ed25519.NewKeyFromSeed(seed), info)
+}
+
+func VerifySignInEd25519(info, pubkey, sig []byte) bool {
+	if len(pubkey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(pubkey), info, sig)
+}
+
+func CalcPubkeyInEd25519(seed []byte) []byte {
+	if len(seed) != ed25519.SeedSize {
+		return nil
+	}
+	return []byte(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+}
+
+func secp256r1Key(privkey []byte) (*ecdsa.PrivateKey, error) {
+	curve := elliptic.P256()
+	d := new(big.Int).SetBytes(privkey)
+	if len(privkey) != 32 || d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("invalid secp256r1 seckey")
+	}
+	key := &ecdsa.PrivateKey{D: d}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(privkey)
+	return key, nil
+}
+
+// SignInSecp256r1 returns r and s of the signature as 32 byte big endian integers,
+// s is kept in the lower half of the curve order so the signature cannot be altered
+func SignInSecp256r1(info, privkey []byte) ([]byte, error) {
+	key, err := secp256r1Key(privkey)
+	if err != nil {
+		return nil, err
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, key, info)
+	if err != nil {
+		return nil, err
+	}
+	n := key.Curve.Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
+
+func VerifySignInSecp256r1(info, pubkey, sig []byte) bool {
+	curve := elliptic.P256()
+	x, y := elliptic.UnmarshalCompressed(curve, pubkey)
+	if x == nil || len(sig) != 64 {
+		return false
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(new(big.Int).Rsh(curve.Params().N, 1)) > 0 {
+		return false
+	}
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, info, r, s)
+}
+
+func CalcPubkeyInSecp256r1(privkey []byte) []byte {
+	key, err := secp256r1Key(privkey)
+	if err != nil {
+		return nil
+	}
+	return elliptic.MarshalCompressed(key.Curve, key.X, key.Y)
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/iost-official/Go-IOS-Protocol/log"
@@ -12,8 +13,79 @@ type SignAlgorithm uint8
 
 const (
 	Secp256k1 SignAlgorithm = iota
+	Ed25519
+	Secp256r1
 )
 
+var ErrUnknownAlgorithm = errors.New("algorithm not exist")
+
+var algorithmNames = map[SignAlgorithm]string{
+	Secp256k1: "secp256k1",
+	Ed25519:   "ed25519",
+	Secp256r1: "secp256r1",
+}
+
+func (a SignAlgorithm) String() string {
+	if name, ok := algorithmNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("SignAlgorithm(%d)", uint8(a))
+}
+
+// ParseSignAlgorithm returns the algorithm called name
+func ParseSignAlgorithm(name string) (SignAlgorithm, error) {
+	for algo, n := range algorithmNames {
+		if n == name {
+			return algo, nil
+		}
+	}
+	return 0, ErrUnknownAlgorithm
+}
+
+// pubkeyTag leads the public keys of the algorithms other than secp256k1. A compressed
+// secp256k1 key starts with 0x02 or 0x03 and an uncompressed one with 0x04, so every
+// public key, and the account id made of it, tells its algorithm.
+func pubkeyTag(algo SignAlgorithm) byte {
+	return 0xe0 | byte(algo)
+}
+
+// CalcPubkey returns the public key of privkey for algo, led by the algorithm tag
+// unless algo is secp256k1
+func CalcPubkey(algo SignAlgorithm, privkey []byte) ([]byte, error) {
+	var pubkey []byte
+	switch algo {
+	case Secp256k1:
+		return CalcPubkeyInSecp256k1(privkey), nil
+	case Ed25519:
+		pubkey = CalcPubkeyInEd25519(privkey)
+	case Secp256r1:
+		pubkey = CalcPubkeyInSecp256r1(privkey)
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+	if pubkey == nil {
+		return nil, fmt.Errorf("invalid %v seckey", algo)
+	}
+	return append([]byte{pubkeyTag(algo)}, pubkey...), nil
+}
+
+// PubkeyAlgorithm returns the algorithm of a public key made by CalcPubkey and the
+// key without its tag
+func PubkeyAlgorithm(pubkey []byte) (SignAlgorithm, []byte, error) {
+	if len(pubkey) == 0 {
+		return 0, nil, ErrUnknownAlgorithm
+	}
+	switch pubkey[0] {
+	case 0x02, 0x03, 0x04:
+		return Secp256k1, pubkey, nil
+	case pubkeyTag(Ed25519):
+		return Ed25519, pubkey[1:], nil
+	case pubkeyTag(Secp256r1):
+		return Secp256r1, pubkey[1:], nil
+	}
+	return 0, nil, ErrUnknownAlgorithm
+}
+
 type Signature struct {
 	Algorithm SignAlgorithm
 
@@ -24,19 +96,40 @@ type Signature struct {
 func Sign(algo SignAlgorithm, info, privkey []byte) (Signature, error) {
 	s := Signature{}
 	s.Algorithm = algo
+	pubkey, err := CalcPubkey(algo, privkey)
+	if err != nil {
+		return s, err
+	}
+	s.Pubkey = pubkey
 	switch algo {
 	case Secp256k1:
-		s.Pubkey = CalcPubkeyInSecp256k1(privkey)
 		s.Sig = SignInSecp256k1(info, privkey)
-		return s, nil
+	case Ed25519:
+		s.Sig = SignInEd25519(info, privkey)
+	case Secp256r1:
+		s.Sig, err = SignInSecp256r1(info, privkey)
+		if err != nil {
+			return s, fmt.Errorf("failed to sign in %v: %v", algo, err)
+		}
+	}
+	if s.Sig == nil {
+		return s, fmt.Errorf("failed to sign in %v", algo)
 	}
-	return s, fmt.Errorf("algorithm not exist")
+	return s, nil
 }
 
 func VerifySignature(info []byte, s Signature) bool {
-	switch s.Algorithm {
+	algo, pubkey, err := PubkeyAlgorithm(s.Pubkey)
+	if err != nil || algo != s.Algorithm {
+		return false
+	}
+	switch algo {
 	case Secp256k1:
-		return VerifySignInSecp256k1(info, s.Pubkey, s.Sig)
+		return VerifySignInSecp256k1(info, pubkey, s.Sig)
+	case Ed25519:
+		return VerifySignInEd25519(info, pubkey, s.Sig)
+	case Secp256r1:
+		return VerifySignInSecp256r1(info, pubkey, s.Sig)
 	}
 	return false
 }
@@ -57,6 +150,9 @@ func (s *Signature) Decode(b []byte) error {
 	s.Algorithm = SignAlgorithm(sr.Algorithm)
 	s.Sig = sr.Sig
 	s.Pubkey = sr.Pubkey
+	if _, ok := algorithmNames[s.Algorithm]; err == nil && !ok {
+		return ErrUnknownAlgorithm
+	}
 	return err
 }
 
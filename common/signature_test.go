@@ -32,5 +32,45 @@ func TestSignature(t *testing.T) {
 			So(sig.Algorithm, ShouldEqual, sig2.Algorithm)
 		})
 
+		Convey("Other algorithms", func() {
+			info := Sha256([]byte("hello"))
+			seckey := Sha256([]byte("seckey"))
+			for _, algo := range []SignAlgorithm{Ed25519, Secp256r1} {
+				sig, err := Sign(algo, info, seckey)
+				So(err, ShouldBeNil)
+				So(sig.Algorithm, ShouldEqual, algo)
+				So(VerifySignature(info, sig), ShouldBeTrue)
+				So(VerifySignature(Sha256([]byte("world")), sig), ShouldBeFalse)
+
+				pubAlgo, _, err := PubkeyAlgorithm(sig.Pubkey)
+				So(err, ShouldBeNil)
+				So(pubAlgo, ShouldEqual, algo)
+
+				var sig2 Signature
+				So(sig2.Decode(sig.Encode()), ShouldBeNil)
+				So(sig2.Algorithm, ShouldEqual, algo)
+				So(VerifySignature(info, sig2), ShouldBeTrue)
+
+				sig2.Algorithm = Secp256k1
+				So(VerifySignature(info, sig2), ShouldBeFalse)
+			}
+
+			name, err := ParseSignAlgorithm("ed25519")
+			So(err, ShouldBeNil)
+			So(name, ShouldEqual, Ed25519)
+			_, err = ParseSignAlgorithm("rsa")
+			So(err, ShouldEqual, ErrUnknownAlgorithm)
+
+			var sig Signature
+			raw := SignatureRaw{9, []byte{1}, []byte{2}}
+			b, _ := raw.Marshal(nil)
+			So(sig.Decode(b), ShouldEqual, ErrUnknownAlgorithm)
+
+			_, err = SignInSecp256r1(info, make([]byte, 32))
+			So(err, ShouldNotBeNil)
+			_, err = SignInSecp256r1(info, seckey[:16])
+			So(err, ShouldNotBeNil)
+		})
+
 	})
 }
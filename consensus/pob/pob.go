@@ -167,7 +167,7 @@ func (p *PoB) genesis(initTime int64) error {
 
 	var code string
 	for k, v := range GenesisAccount {
-		code += fmt.Sprintf("@PutHM iost %v f%v\n", k, v)
+		code += fmt.Sprintf("@PutHM iost %v %v\n", k, state.DecimalFromInt(v).EncodeString())
 	}
 
 	lc := lua.NewContract(vm.ContractInfo{Prefix: "", GasLimit: 0, Price: 0, Publisher: ""}, code, main)
@@ -180,7 +180,7 @@ func (p *PoB) genesis(initTime int64) error {
 
 	genesis := &block.Block{
 		Head: block.BlockHead{
-			Version: 0,
+			Version: block.VersionAt(0),
 			Number:  0,
 			Time:    initTime,
 		},
@@ -267,39 +267,37 @@ func (p *PoB) scheduleLoop() {
 			if wid == p.account.ID {
 
 				bc := p.blockCache.LongestChain()
-				iter := bc.Iterator()
-				for {
-					block := iter.Next()
-					if block == nil {
-						break
-					}
-					confirmedBlockchainLength.Set(float64(p.blockCache.ConfirmedLength()))
-					p.log.I("CBC ConfirmedLength: %v, block Number: %v, witness: %v", p.blockCache.ConfirmedLength(), block.Head.Number, block.Head.Witness)
-				}
+				top := bc.Top()
+				confirmedBlockchainLength.Set(float64(p.blockCache.ConfirmedLength()))
+				p.log.I("CBC ConfirmedLength: %v, block Number: %v, witness: %v", p.blockCache.ConfirmedLength(), top.Head.Number, top.Head.Witness)
 
 				pool := p.blockCache.LongestPool()
-				blk := p.genBlock(p.account, bc, pool)
-
-				p.globalDynamicProperty.update(&blk.Head)
-				p.log.I("Generating block, current timestamp: %v number: %v", currentTimestamp, blk.Head.Number)
-
-				bb := blk.Encode()
-				msg := message.Message{ReqType: int32(ReqNewBlock), Body: bb}
-				log.Log.I("Block size: %v, TrNum: %v", len(bb), len(blk.Content))
-				go p.router.Broadcast(msg)
-				p.chBlock <- msg
-				p.log.I("Broadcasted block, current timestamp: %v number: %v", currentTimestamp, blk.Head.Number)
+				blk, err := p.genBlock(p.account, bc, pool)
+				if err != nil {
+					// a block the peers would refuse is not broadcast, the slot is skipped
+					p.log.E("Gen Block failed, skip slot %v: %v", currentTimestamp, err)
+				} else {
+					p.globalDynamicProperty.update(&blk.Head)
+					p.log.I("Generating block, current timestamp: %v number: %v", currentTimestamp, blk.Head.Number)
+
+					bb := blk.Encode()
+					msg := message.Message{ReqType: int32(ReqNewBlock), Body: bb}
+					log.Log.I("Block size: %v, TrNum: %v", len(bb), len(blk.Content))
+					go p.router.Broadcast(msg)
+					p.chBlock <- msg
+					p.log.I("Broadcasted block, current timestamp: %v number: %v", currentTimestamp, blk.Head.Number)
+				}
 			}
 			nextSchedule = timeUntilNextSchedule(&p.globalStaticProperty, &p.globalDynamicProperty, time.Now().Unix())
 		}
 	}
 }
 
-func (p *PoB) genBlock(acc Account, bc block.Chain, pool state.Pool) *block.Block {
+func (p *PoB) genBlock(acc Account, bc block.Chain, pool state.Pool) (*block.Block, error) {
 	limitTime := time.NewTicker(((SlotLength/3 - 1) + 1) * time.Second)
 	lastBlk := bc.Top()
 	blk := block.Block{Content: []Tx{}, Head: block.BlockHead{
-		Version:    0,
+		Version:    block.VersionAt(lastBlk.Head.Number + 1),
 		ParentHash: lastBlk.HeadHash(),
 		Number:     lastBlk.Head.Number + 1,
 		Witness:    acc.ID,
@@ -313,6 +311,8 @@ func (p *PoB) genBlock(acc Account, bc block.Chain, pool state.Pool) *block.Bloc
 	vc.BlockHeight = blk.Head.Number
 	vc.Witness = vm.IOSTAccount(acc.ID)
 
+	blockTime := slotToNano(blk.Head.Time)
+
 	txCnt := TxPerBlk + rand.Intn(500)
 	var tx TransactionsList
 	if txpool.TxPoolS != nil {
@@ -335,6 +335,9 @@ func (p *PoB) genBlock(acc Account, bc block.Chain, pool state.Pool) *block.Bloc
 					p.log.I("Gen Block Tx Number Limit.")
 					break ForEnd
 				}
+				if t.Expired(blockTime) || t.VerifyLegacyAt(blk.Head.Number) != nil {
+					continue
+				}
 				if err := blockcache.StdCacheVerifier(t, spool1, vc); err == nil {
 					blk.Content = append(blk.Content, *t)
 				}
@@ -342,17 +345,25 @@ func (p *PoB) genBlock(acc Account, bc block.Chain, pool state.Pool) *block.Bloc
 		}
 	}
 	blk.Head.TreeHash = blk.CalculateTreeHash()
+	if blk.Head.Extended() {
+		blk.Head.TxCount = int64(len(blk.Content))
+	}
+	defer blockcache.CleanStdVerifier()
+	if _, err := blockcache.StdBlockProducer(&blk, pool); err != nil {
+		return nil, fmt.Errorf("verify generated block failed: %v", err)
+	}
 	headInfo := generateHeadInfo(blk.Head)
-	sig, _ := common.Sign(common.Secp256k1, headInfo, acc.Seckey)
+	sig, err := acc.Sign(headInfo)
+	if err != nil {
+		return nil, fmt.Errorf("sign generated block failed: %v", err)
+	}
 	blk.Head.Signature = sig.Encode()
 
-	blockcache.CleanStdVerifier()
-
 	generatedBlockCount.Inc()
 
 	Data.ClearServi(blk.Head.Witness)
 
-	return &blk
+	return &blk, nil
 }
 
 func generateHeadInfo(head block.BlockHead) []byte {
@@ -368,9 +379,23 @@ func generateHeadInfo(head block.BlockHead) []byte {
 	info = append(info, head.ParentHash...)
 	info = append(info, head.TreeHash...)
 	info = append(info, head.Info...)
+	info = append(info, head.StateRoot...)
+	info = append(info, head.ReceiptsRoot...)
+	if head.Extended() {
+		extInfo := make([]byte, 16)
+		binary.BigEndian.PutUint64(extInfo, uint64(head.GasUsed))
+		binary.BigEndian.PutUint64(extInfo[8:], uint64(head.TxCount))
+		info = append(info, extInfo...)
+	}
 	return common.Sha256(info)
 }
 
+// slotToNano converts a block time slot to unix nanoseconds, the unit of tx times
+func slotToNano(slot int64) int64 {
+	ts := Timestamp{Slot: slot}
+	return ts.ToUnixSec() * int64(time.Second)
+}
+
 func (p *PoB) blockVerify(blk *block.Block, parent *block.Block, pool state.Pool) (state.Pool, error) {
 	// verify block head
 	if err := blockcache.VerifyBlockHead(blk, parent); err != nil {
@@ -395,6 +420,25 @@ func (p *PoB) blockVerify(blk *block.Block, parent *block.Block, pool state.Pool
 	if !common.VerifySignature(headInfo, signature) {
 		return nil, errors.New("wrong signature")
 	}
+
+	// verify txs belong to this chain, are not legacy txs past LegacyEndHeight and
+	// had not expired by the block time
+	blockTime := slotToNano(blk.Head.Time)
+	for i := range blk.Content {
+		if err := blk.Content[i].VerifyChain(); err != nil {
+			return nil, err
+		}
+		if err := blk.Content[i].VerifyLegacyAt(blk.Head.Number); err != nil {
+			return nil, err
+		}
+		if blk.Content[i].Expired(blockTime) {
+			return nil, ErrExpired
+		}
+	}
+	// verify tx signatures, those checked when the txs entered the tx pool are skipped
+	if err := blockcache.StdSigVerifier.VerifyTxs(blk.Content); err != nil {
+		return nil, err
+	}
 	newPool, err := blockcache.StdBlockVerifier(blk, pool)
 	if err != nil {
 		return nil, err
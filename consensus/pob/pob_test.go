@@ -1,6 +1,7 @@
 package pob
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/iost-official/Go-IOS-Protocol/common"
 	"github.com/iost-official/Go-IOS-Protocol/consensus/common"
 	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/blockcache"
 	"github.com/iost-official/Go-IOS-Protocol/core/message"
 	"github.com/iost-official/Go-IOS-Protocol/core/mocks"
 	"github.com/iost-official/Go-IOS-Protocol/core/state"
@@ -188,6 +190,7 @@ func envinit(t *testing.T) (*PoB, []account.Account, []string, *txpool.TxPoolSer
 		panic("block.Instance error")
 	}
 
+	state.DBTarget = "mem"
 	err = state.PoolInstance()
 	if err != nil {
 		panic("state.PoolInstance error")
@@ -224,13 +227,88 @@ func TestRunGenerateBlock(t *testing.T) {
 
 		bc := p.blockCache.LongestChain()
 		pool := p.blockCache.LongestPool()
-		blk := p.genBlock(p.account, bc, pool)
+		blk, err := p.genBlock(p.account, bc, pool)
+		So(err, ShouldBeNil)
 		So(len(blk.Content), ShouldEqual, 0)
 		//So(len(blk.Content), ShouldEqual, 1)
 		//So(blk.Content[0].Nonce, ShouldEqual, 998)
 	})
 }
 
+func TestGenerateBlockOfOneAccount(t *testing.T) {
+	Convey("Test of generating a block with txs of one account", t, func() {
+		p, _, _, txpool := envinit(t)
+		pool := p.blockCache.LongestPool()
+		pool.PutHM("iost", state.Key(p.account.ID), state.DecimalFromInt(1000))
+		for nonce := 0; nonce < 3; nonce++ {
+			_tx := genTxMsg(p, nonce)
+			txpool.AddTransaction(&_tx)
+		}
+		time.Sleep(time.Second * 1)
+
+		bc := p.blockCache.LongestChain()
+		blk, err := p.genBlock(p.account, bc, pool)
+		So(err, ShouldBeNil)
+		So(len(blk.Content), ShouldEqual, 3)
+		for i, t := range blk.Content {
+			So(t.Nonce, ShouldEqual, i)
+		}
+	})
+}
+
+func TestGenerateBlockOnBrokenPool(t *testing.T) {
+	Convey("Test of generating a block on a pool that fails", t, func() {
+		p, _, _, _ := envinit(t)
+		bc := p.blockCache.LongestChain()
+		broken := core_mock.NewMockPool(NewController(t))
+		broken.EXPECT().Copy().AnyTimes().Return(broken)
+		broken.EXPECT().MergeParent().AnyTimes().Return(nil, errors.New("broken pool"))
+		blk, err := p.genBlock(p.account, bc, broken)
+		So(err, ShouldNotBeNil)
+		So(blk, ShouldBeNil)
+	})
+}
+
+func TestGenerateVersion2Block(t *testing.T) {
+	Convey("Test of generating a version 2 block", t, func() {
+		height := block.Version2Height
+		block.Version2Height = 0
+		defer func() { block.Version2Height = height }()
+
+		p, _, _, _ := envinit(t)
+		bc := p.blockCache.LongestChain()
+		pool := p.blockCache.LongestPool()
+		blk, err := p.genBlock(p.account, bc, pool)
+		So(err, ShouldBeNil)
+		So(blk.Head.Extended(), ShouldBeTrue)
+		So(blk.Head.StateRoot, ShouldNotBeEmpty)
+		So(blk.Head.ReceiptsRoot, ShouldResemble, block.ReceiptsRoot(nil))
+
+		So(blockcache.VerifyBlockHead(blk, bc.Top()), ShouldBeNil)
+		_, err = blockcache.StdBlockVerifier(blk, pool)
+		So(err, ShouldBeNil)
+
+		root := blk.Head.StateRoot
+		blk.Head.StateRoot = nil
+		_, err = blockcache.StdBlockVerifier(blk, pool)
+		So(err, ShouldNotBeNil)
+		blk.Head.StateRoot = root
+
+		Convey("state root before StateRootHeight", func() {
+			defer func(h int64) { block.StateRootHeight = h }(block.StateRootHeight)
+			block.StateRootHeight = blk.Head.Number + 1
+			blk, err := p.genBlock(p.account, bc, pool)
+			So(err, ShouldBeNil)
+			So(blk.Head.StateRoot, ShouldBeEmpty)
+			_, err = blockcache.StdBlockVerifier(blk, pool)
+			So(err, ShouldBeNil)
+			blk.Head.StateRoot = root
+			_, err = blockcache.StdBlockVerifier(blk, pool)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
 func TestAddSinglesBlock(t *testing.T) {
 	Convey("Test of Add singles block", t, func() {
 		verify := func(blk *block.Block, parent *block.Block, pool state.Pool) (state.Pool, error) {
@@ -281,9 +359,10 @@ func TestRunConfirmBlock(t *testing.T) {
 			bc := p.blockCache.LongestChain()
 			pool := p.blockCache.LongestPool()
 
-			blk := p.genBlock(p.account, bc, pool)
+			blk, err := p.genBlock(p.account, bc, pool)
+			So(err, ShouldBeNil)
 			p.globalDynamicProperty.update(&blk.Head)
-			err := p.blockCache.Add(blk, p.blockVerify)
+			err = p.blockCache.Add(blk, p.blockVerify)
 			fmt.Println(err)
 		}
 
@@ -297,9 +376,10 @@ func TestRunConfirmBlock(t *testing.T) {
 
 			bc := p.blockCache.LongestChain()
 			pool := p.blockCache.LongestPool()
-			blk := p.genBlock(accList[i], bc, pool)
+			blk, err := p.genBlock(accList[i], bc, pool)
+			So(err, ShouldBeNil)
 			p.globalDynamicProperty.update(&blk.Head)
-			err := p.blockCache.Add(blk, p.blockVerify)
+			err = p.blockCache.Add(blk, p.blockVerify)
 			fmt.Println(err)
 			if i == 1 {
 				So(p.blockCache.ConfirmedLength(), ShouldEqual, initConfLength)
@@ -332,7 +412,8 @@ func TestRunMultipleBlocks(t *testing.T) {
 				wit = witnessOfTime(&p.globalStaticProperty, &p.globalDynamicProperty, currentTimestamp)
 			}
 
-			blk := p.genBlock(p.account, bc, pool)
+			blk, err := p.genBlock(p.account, bc, pool)
+			So(err, ShouldBeNil)
 			p.globalDynamicProperty.update(&blk.Head)
 
 			blk.Head.Time = int64(i)
@@ -341,7 +422,7 @@ func TestRunMultipleBlocks(t *testing.T) {
 			sig, _ := common.Sign(common.Secp256k1, headInfo, p.account.Seckey)
 			blk.Head.Signature = sig.Encode()
 
-			err := p.blockCache.Add(blk, p.blockVerify)
+			err = p.blockCache.Add(blk, p.blockVerify)
 			fmt.Println(err)
 		}
 	})
@@ -488,6 +569,7 @@ func envInit(b *testing.B) (*PoB, []account.Account, []string, *txpool.TxPoolSer
 		panic("block.Instance error")
 	}
 
+	state.DBTarget = "mem"
 	err = state.PoolInstance()
 	if err != nil {
 		panic("state.PoolInstance error")
@@ -573,8 +655,9 @@ func genBlocks(p *PoB, accountList []account.Account, witnessList []string, n in
 			Time:       slot + int64(i),
 		}}
 
-		for i := 0; i < txCnt; i++ {
-			blk.Content = append(blk.Content, genTx(p, i))
+		// nonces keep growing across blocks, a used nonce is rejected
+		for j := 0; j < txCnt; j++ {
+			blk.Content = append(blk.Content, genTx(p, i*txCnt+j))
 		}
 		blk.Head.TreeHash = blk.CalculateTreeHash()
 		headInfo := generateHeadInfo(blk.Head)
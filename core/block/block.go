@@ -14,6 +14,10 @@ import (
 type Block struct {
 	Head    BlockHead
 	Content []tx.Tx //TODO:make it general for other structs
+
+	// Receipts are filled in when the block is verified and stored when it is pushed,
+	// they are not part of the block encoding
+	Receipts []*Receipt
 }
 
 func (d *Block) String() string {
@@ -33,7 +37,12 @@ func (d *Block) String() string {
 	return str
 }
 
+// CalculateTreeHash returns the Merkle root over the hashes of the block txs for a
+// version 2 head, older heads keep the hash over the publisher signatures of the txs
 func (d *Block) CalculateTreeHash() []byte {
+	if d.Head.Extended() {
+		return d.txTree().Root()
+	}
 	treeHash := make([]byte, 0)
 	for _, tx := range d.Content {
 		treeHash = append(treeHash, tx.Publisher.Sig...)
@@ -46,8 +55,15 @@ func (d *Block) Encode() []byte {
 	for _, t := range d.Content {
 		c = append(c, t.Encode())
 	}
-	br := BlockRaw{d.Head, c}
-	b, err := br.Marshal(nil)
+	var b []byte
+	var err error
+	if d.Head.Extended() {
+		br := BlockRaw{d.Head, c}
+		b, err = br.Marshal(nil)
+	} else {
+		br := BlockRawV0{d.Head.legacy(), c}
+		b, err = br.Marshal(nil)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -55,16 +71,30 @@ func (d *Block) Encode() []byte {
 }
 
 func (d *Block) Decode(bin []byte) (err error) {
-	var br BlockRaw
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("%v", r)
 		}
 	}()
 
-	_, err = br.Unmarshal(bin)
-	d.Head = br.Head
-	for _, t := range br.Content {
+	version, err := encodedVersion(bin)
+	if err != nil {
+		return err
+	}
+	var content [][]byte
+	if version >= Version2 {
+		var br BlockRaw
+		_, err = br.Unmarshal(bin)
+		d.Head, content = br.Head, br.Content
+	} else {
+		var br BlockRawV0
+		_, err = br.Unmarshal(bin)
+		d.Head, content = br.Head.upgrade(), br.Content
+	}
+	if err != nil {
+		return err
+	}
+	for _, t := range content {
 		var tt tx.Tx
 		err = tt.Decode(t)
 		if err != nil {
@@ -115,17 +145,44 @@ func (d *Block) GetAllContract() []vm.Contract {
 	return allContract
 }
 
+// Encode uses the layout of the head version, heads before version 2 leave out the extended fields
 func (d *BlockHead) Encode() []byte {
-	bin, err := d.Marshal(nil)
+	var bin []byte
+	var err error
+	if d.Extended() {
+		bin, err = d.Marshal(nil)
+	} else {
+		legacy := d.legacy()
+		bin, err = legacy.Marshal(nil)
+	}
 	if err != nil {
 		panic(err)
 	}
 	return bin
 }
 
-func (d *BlockHead) Decode(bin []byte) error {
-	_, err := d.Unmarshal(bin)
-	return err
+func (d *BlockHead) Decode(bin []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	version, err := encodedVersion(bin)
+	if err != nil {
+		return err
+	}
+	if version >= Version2 {
+		_, err = d.Unmarshal(bin)
+		return err
+	}
+	var legacy BlockHeadV0
+	_, err = legacy.Unmarshal(bin)
+	if err != nil {
+		return err
+	}
+	*d = legacy.upgrade()
+	return nil
 }
 
 func (d *BlockHead) Hash() []byte {
@@ -17,6 +17,9 @@ var (
 
 	blockNumberPrefix = []byte("n") //blockNumberPrefix + block number -> block hash
 	blockPrefix       = []byte("H") //blockHashPrefix + block hash -> block data
+	statePatchPrefix  = []byte("s") //statePatchPrefix + block hash -> state patch of block
+	receiptsPrefix    = []byte("r") //receiptsPrefix + block hash -> receipts of block
+	txReceiptPrefix   = []byte("R") //txReceiptPrefix + tx hash -> receipt of tx
 )
 
 type ChainImpl struct {
@@ -75,52 +78,265 @@ func Instance() (Chain, error) {
 			return
 		}
 
-		BChain = &ChainImpl{db: ldb, length: length, tx: txDb}
-
-		BChain.CheckLength()
+		chain := &ChainImpl{db: ldb, length: length, tx: txDb}
+		chain.CheckLength()
+		if er := chain.recoverTxs(); er != nil {
+			err = fmt.Errorf("failed to recover txs of top block: %v", er)
+			return
+		}
+		BChain = chain
 	})
 
 	return BChain, err
 }
 
 func (b *ChainImpl) Push(block *Block) error {
+	err := b.push(block, nil)
+	if err != nil {
+		return err
+	}
+
+	state.StdPool.Put(state.Key("BlockNum"), state.MakeVInt(int(block.Head.Number)))
+	state.StdPool.Put(state.Key("BlockHash"), state.MakeVByte(block.HeadHash()))
+	state.StdPool.Flush()
+
+	return nil
+}
+
+// PushWithPatch stores block together with the state patch it produced in one batch,
+// the state database can then be brought up to date from the stored patch after a crash
+func (b *ChainImpl) PushWithPatch(block *Block, patch state.Patch) error {
+	return b.push(block, &patch)
+}
+
+// recoverTxs puts the txs of the top block in the tx database again, push writes
+// them after the block so a crash in between leaves them out
+func (b *ChainImpl) recoverTxs() error {
+	top := b.Top()
+	if top == nil {
+		return nil
+	}
+	for i := range top.Content {
+		if err := b.tx.Add(&top.Content[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayState brings pool, flushed up to the block before from, up to the top of
+// chain with the state patches stored by PushWithPatch and flushes it after each
+// block. The state of a block is flushed after the block itself, the replay repairs
+// a crash in between on start. It returns the number of the first block stored
+// without a patch, the blocks from there on have to be executed again.
+func ReplayState(chain Chain, pool state.Pool, from uint64) (uint64, error) {
+	for i := from; i < chain.Length(); i++ {
+		blk := chain.GetBlockByNumber(i)
+		if blk == nil {
+			return i, nil
+		}
+		patch, err := chain.GetStatePatch(blk.HeadHash())
+		if err != nil {
+			return i, nil
+		}
+		if err := patch.Apply(pool); err != nil {
+			return i, err
+		}
+		if err := pool.Flush(); err != nil {
+			return i, err
+		}
+	}
+	return chain.Length(), nil
+}
+
+func (b *ChainImpl) push(block *Block, patch *state.Patch) error {
 
 	hash := block.HeadHash()
 	number := uint64(block.Head.Number)
+	if number < b.length {
+		return fmt.Errorf("block %v is below the chain length %v, roll back first", number, b.length)
+	}
 
-	err := b.db.Put(append(blockNumberPrefix, strconv.FormatUint(number, 10)...), hash)
+	batch := b.db.NewBatch()
+	err := batch.Put(append(blockNumberPrefix, strconv.FormatUint(number, 10)...), hash)
 	if err != nil {
 		return fmt.Errorf("failed to Put block hash err[%v]", err)
 	}
 
-	err = b.db.Put(append(blockPrefix, hash...), block.Encode())
+	err = batch.Put(append(blockPrefix, hash...), block.Encode())
 	if err != nil {
 		return fmt.Errorf("failed to Put block data")
 	}
 
-	//put all the tx of this block to txdb
+	if patch != nil {
+		err = batch.Put(append(statePatchPrefix, hash...), patch.Encode())
+		if err != nil {
+			return fmt.Errorf("failed to Put state patch")
+		}
+	}
+
+	if block.Receipts != nil {
+		err = batch.Put(append(receiptsPrefix, hash...), encodeReceipts(block.Receipts))
+		if err != nil {
+			return fmt.Errorf("failed to Put receipts")
+		}
+		for _, r := range block.Receipts {
+			err = batch.Put(append(txReceiptPrefix, r.TxHash...), r.Encode())
+			if err != nil {
+				return fmt.Errorf("failed to Put receipt")
+			}
+		}
+	}
+
+	if IndexEnabled {
+		err = putIndexes(batch, block)
+		if err != nil {
+			return fmt.Errorf("failed to Put block indexes err:%v", err)
+		}
+	}
+
+	var lenB = make([]byte, 128)
+	binary.BigEndian.PutUint64(lenB, number+1)
+	err = batch.Put(blockLength, lenB)
+	if err != nil {
+		return fmt.Errorf("failed to Put blockLength err:%v", err)
+	}
+
+	err = batch.Write()
+	if err != nil {
+		return fmt.Errorf("failed to write block batch err:%v", err)
+	}
+	log.Log.I("[block] push length:%v block num:%v ", b.length, number)
+	b.length = number + 1
+
+	// the txs go to their own database after the block, recoverTxs puts them there
+	// again when a crash came in between
 	for _, ctx := range block.Content {
 		if err := b.tx.Add(&ctx); err != nil {
 			return fmt.Errorf("failed to add tx %v", err)
 		}
+	}
 
+	// add servi
+	if tx.Data != nil {
+		go tx.Data.AddServi(block.Content)
 	}
 
-	err = b.lengthAdd(number)
-	if err != nil {
-		return fmt.Errorf("failed to lengthAdd %v", err)
+	return nil
+}
+
+// Rollback removes the blocks above number toHeight together with their txs,
+// receipts and index entries, after bringing the state back to block toHeight.
+// Blocks are removed from the top down, an interrupted Rollback leaves a
+// shorter chain that can be rolled back again.
+func (b *ChainImpl) Rollback(toHeight uint64) error {
+	if toHeight+1 >= b.length {
+		return nil
+	}
+	if state.StdPool != nil {
+		err := state.StdPool.Revert(int64(toHeight))
+		if err != nil {
+			return fmt.Errorf("failed to revert state to block %v err:%v", toHeight, err)
+		}
 	}
 
-	state.StdPool.Put(state.Key("BlockNum"), state.MakeVInt(int(block.Head.Number)))
-	state.StdPool.Put(state.Key("BlockHash"), state.MakeVByte(block.HeadHash()))
-	state.StdPool.Flush()
+	for number := b.length - 1; number > toHeight; number-- {
+		hash := b.GetHashByNumber(number)
+		block := b.GetBlockByHash(hash)
+		if block == nil {
+			return fmt.Errorf("block %v not found", number)
+		}
 
-	// add servi
-	go tx.Data.AddServi(block.Content)
+		for i := range block.Content {
+			if err := b.tx.Del(&block.Content[i]); err != nil {
+				return fmt.Errorf("failed to delete tx %v", err)
+			}
+		}
+
+		keys := [][]byte{
+			append(blockNumberPrefix, b.getLengthBytes(number)...),
+			append(blockPrefix, hash...),
+			append(statePatchPrefix, hash...),
+			append(receiptsPrefix, hash...),
+		}
+		if receipts, err := b.GetReceipts(hash); err == nil {
+			for _, r := range receipts {
+				keys = append(keys, append(append([]byte{}, txReceiptPrefix...), r.TxHash...))
+			}
+		}
+		batch := b.db.NewBatch()
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				return fmt.Errorf("failed to Delete block data err:%v", err)
+			}
+		}
+		err := deleteIndexes(batch, block)
+		if err != nil {
+			return fmt.Errorf("failed to Delete block indexes err:%v", err)
+		}
 
+		var lenB = make([]byte, 128)
+		binary.BigEndian.PutUint64(lenB, number)
+		err = batch.Put(blockLength, lenB)
+		if err != nil {
+			return fmt.Errorf("failed to Put blockLength err:%v", err)
+		}
+		err = batch.Write()
+		if err != nil {
+			return fmt.Errorf("failed to write rollback batch err:%v", err)
+		}
+		log.Log.I("[block] rollback block num:%v", number)
+		b.length = number
+	}
 	return nil
 }
 
+// GetStatePatch returns the state patch stored with the block by PushWithPatch
+func (b *ChainImpl) GetStatePatch(blockHash []byte) (*state.Patch, error) {
+	bin, err := b.db.Get(append(statePatchPrefix, blockHash...))
+	if err != nil {
+		return nil, err
+	}
+	if len(bin) == 0 {
+		return nil, fmt.Errorf("state patch empty")
+	}
+	var patch state.Patch
+	err = patch.Decode(bin)
+	if err != nil {
+		return nil, err
+	}
+	return &patch, nil
+}
+
+// GetReceipts returns the receipts of the block in tx order
+func (b *ChainImpl) GetReceipts(blockHash []byte) ([]*Receipt, error) {
+	bin, err := b.db.Get(append(receiptsPrefix, blockHash...))
+	if err != nil {
+		return nil, err
+	}
+	if len(bin) == 0 {
+		return nil, fmt.Errorf("receipts empty")
+	}
+	return decodeReceipts(bin)
+}
+
+// GetReceipt returns the receipt of the tx with hash txHash
+func (b *ChainImpl) GetReceipt(txHash []byte) (*Receipt, error) {
+	bin, err := b.db.Get(append(txReceiptPrefix, txHash...))
+	if err != nil {
+		return nil, err
+	}
+	if len(bin) == 0 {
+		return nil, fmt.Errorf("receipt empty")
+	}
+	var r Receipt
+	err = r.Decode(bin)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
 func (b *ChainImpl) Length() uint64 {
 	return b.length
 }
@@ -167,23 +383,6 @@ func (b *ChainImpl) GetTx(hash []byte) (*tx.Tx, error) {
 	return b.tx.Get(hash)
 }
 
-func (b *ChainImpl) lengthAdd(blockNum uint64) error {
-
-	log.Log.E("[block] lengthAdd length:%v block num:%v ", b.length, blockNum)
-
-	b.length = blockNum + 1
-
-	var tmpByte = make([]byte, 128)
-	binary.BigEndian.PutUint64(tmpByte, b.length)
-
-	err := b.db.Put(blockLength, tmpByte)
-	if err != nil {
-		b.length--
-	}
-
-	return nil
-}
-
 func (b *ChainImpl) getLengthBytes(length uint64) []byte {
 
 	return []byte(strconv.FormatUint(length, 10))
@@ -271,7 +470,3 @@ func (b *ChainImpl) GetBlockByteByHash(blockHash []byte) ([]byte, error) {
 
 	return block, nil
 }
-
-func (b *ChainImpl) Iterator() ChainIterator {
-	return nil
-}
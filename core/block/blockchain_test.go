@@ -7,6 +7,9 @@ import (
 	"github.com/iost-official/Go-IOS-Protocol/common"
 	"github.com/iost-official/Go-IOS-Protocol/core/state"
 	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/db"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
+	"github.com/iost-official/Go-IOS-Protocol/vm/lua"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -46,6 +49,7 @@ func TestChainImpl(t *testing.T) {
 			Time:       201222,
 		}}
 
+		state.DBTarget = "mem"
 		err = state.PoolInstance()
 		if err != nil {
 			panic("state.PoolInstance error")
@@ -104,5 +108,113 @@ func TestChainImpl(t *testing.T) {
 			So(string(block.Head.Signature), ShouldEqual, string(tBlock.Head.Signature))
 			So(string(block.Head.Time), ShouldEqual, string(tBlock.Head.Time))
 		})
+
+		Convey("Iterator", func() {
+			length := bc.Length()
+
+			iter := bc.Iterator()
+			block := iter.Next()
+			So(block, ShouldNotBeNil)
+			So(block.Head.Number, ShouldEqual, int64(length)-1)
+
+			iter = bc.ForwardIterator(length - 1)
+			block = iter.Next()
+			So(block, ShouldNotBeNil)
+			So(block.Head.Number, ShouldEqual, int64(length)-1)
+			So(iter.Next(), ShouldBeNil)
+		})
+
+		Convey("Range", func() {
+			length := bc.Length()
+
+			stream := bc.Range(length-1, length+10)
+			So(stream.Next(), ShouldBeTrue)
+			So(stream.Number(), ShouldEqual, length-1)
+			So(string(stream.Hash()), ShouldEqual, string(bc.GetHashByNumber(length-1)))
+			block, err := stream.Block()
+			So(err, ShouldBeNil)
+			So(block.Head.Number, ShouldEqual, int64(length)-1)
+			So(stream.Next(), ShouldBeFalse)
+			So(stream.Error(), ShouldBeNil)
+		})
+
+		Convey("Indexes", func() {
+			length := bc.Length()
+
+			count, err := bc.(*ChainImpl).RebuildIndexes()
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, length)
+
+			numbers, err := bc.GetBlockNumbersByWitness(tBlock.Head.Witness, length-1, 10)
+			So(err, ShouldBeNil)
+			So(numbers, ShouldResemble, []uint64{length - 1})
+
+			number, err := bc.GetBlockNumberByTime(tBlock.Head.Time + 1000)
+			So(err, ShouldBeNil)
+			So(number, ShouldEqual, length-1)
+
+			_, err = bc.GetBlockNumberByTime(tBlock.Head.Time - 1)
+			So(err, ShouldEqual, ErrIndexNotFound)
+
+			_, _, err = bc.GetTxLocation([]byte("no such tx"))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Rollback", func() {
+			state.PatchDb, _ = db.NewMemDatabase()
+			defer func() { state.PatchDb = nil }()
+			length := bc.Length()
+
+			tBlock.Head.Number = int64(length)
+			So(bc.Push(&tBlock), ShouldBeNil)
+			tBlock.Head.Number = int64(length) + 1
+			tBlock.Head.Time = 201223
+			So(bc.Push(&tBlock), ShouldBeNil)
+
+			So(bc.Rollback(length), ShouldBeNil)
+			So(bc.Length(), ShouldEqual, length+1)
+			So(bc.GetBlockByNumber(length+1), ShouldBeNil)
+			bn, err := state.StdPool.Get(state.Key("BlockNum"))
+			So(err, ShouldBeNil)
+			So(bn.EncodeString(), ShouldEqual, state.MakeVInt(int(length)).EncodeString())
+
+			tBlock.Head.Number = int64(length)
+			So(bc.Push(&tBlock), ShouldNotBeNil)
+			tBlock.Head.Number = int64(length) + 1
+			So(bc.Push(&tBlock), ShouldBeNil)
+		})
+
+		Convey("Recover", func() {
+			length := bc.Length()
+			main := lua.NewMethod(2, "main", 0, 1)
+			lc := lua.NewContract(vm.ContractInfo{Prefix: "recover", GasLimit: 100, Price: 1, Publisher: vm.IOSTAccount("a")}, "function main() return 0 end", main)
+			t1 := tx.NewTx(1, &lc)
+			rBlock := tBlock
+			rBlock.Head.Number = int64(length)
+			rBlock.Content = []tx.Tx{t1}
+			sp := state.StdPool.Copy()
+			sp.Put("recover", state.MakeVInt(1))
+			So(bc.PushWithPatch(&rBlock, sp.GetPatch()), ShouldBeNil)
+
+			So(txDb.Del(&t1), ShouldBeNil)
+			ok, _ := bc.HasTx(&t1)
+			So(ok, ShouldBeFalse)
+			So(bc.(*ChainImpl).recoverTxs(), ShouldBeNil)
+			ok, _ = bc.HasTx(&t1)
+			So(ok, ShouldBeTrue)
+
+			mdb, _ := db.NewMemDatabase()
+			pool := state.NewPool(state.NewDatabase(mdb))
+			next, err := ReplayState(bc, pool, length)
+			So(err, ShouldBeNil)
+			So(next, ShouldEqual, length+1)
+			v, err := pool.Get("recover")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i1")
+
+			next, err = ReplayState(bc, pool, length-1)
+			So(err, ShouldBeNil)
+			So(next, ShouldEqual, length-1)
+		})
 	})
 }
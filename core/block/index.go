@@ -0,0 +1,174 @@
+package block
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+	"github.com/iost-official/Go-IOS-Protocol/log"
+)
+
+// The secondary indexes of the block database, they are maintained by Push when
+// IndexEnabled is set and can be backfilled with RebuildIndexes
+var (
+	witnessIndexPrefix = []byte("w") //witnessIndexPrefix + witness + 0x00 + block number -> nil
+	timeIndexPrefix    = []byte("t") //timeIndexPrefix + time slot -> block number
+	txIndexPrefix      = []byte("x") //txIndexPrefix + tx hash -> block number + position in block
+
+	ErrIndexNotFound = errors.New("not found in index")
+)
+
+// IndexEnabled turns on the witness, time and tx indexes of the block database
+var IndexEnabled bool
+
+// rebuildBatchSize is the number of blocks indexed per batch by RebuildIndexes
+const rebuildBatchSize = 1000
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
+func witnessIndexKey(witness string, number uint64) []byte {
+	key := append(witnessPrefix(witness), uint64Bytes(number)...)
+	return key
+}
+
+func witnessPrefix(witness string) []byte {
+	key := make([]byte, 0, len(witnessIndexPrefix)+len(witness)+1)
+	key = append(key, witnessIndexPrefix...)
+	key = append(key, witness...)
+	return append(key, 0x00)
+}
+
+func timeIndexKey(slot int64) []byte {
+	return append(append([]byte{}, timeIndexPrefix...), uint64Bytes(uint64(slot))...)
+}
+
+func txIndexKey(txHash []byte) []byte {
+	return append(append([]byte{}, txIndexPrefix...), txHash...)
+}
+
+// putIndexes adds the index entries of block to batch
+func putIndexes(batch db.Batch, block *Block) error {
+	number := uint64(block.Head.Number)
+	if err := batch.Put(witnessIndexKey(block.Head.Witness, number), []byte{}); err != nil {
+		return err
+	}
+	if err := batch.Put(timeIndexKey(block.Head.Time), uint64Bytes(number)); err != nil {
+		return err
+	}
+	for i := range block.Content {
+		loc := append(uint64Bytes(number), uint64Bytes(uint64(i))...)
+		if err := batch.Put(txIndexKey(block.Content[i].Hash()), loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteIndexes removes the index entries of block from batch
+func deleteIndexes(batch db.Batch, block *Block) error {
+	if err := batch.Delete(witnessIndexKey(block.Head.Witness, uint64(block.Head.Number))); err != nil {
+		return err
+	}
+	if err := batch.Delete(timeIndexKey(block.Head.Time)); err != nil {
+		return err
+	}
+	for i := range block.Content {
+		if err := batch.Delete(txIndexKey(block.Content[i].Hash())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBlockNumbersByWitness returns up to limit numbers of blocks produced by witness,
+// in ascending order starting at block number from
+func (b *ChainImpl) GetBlockNumbersByWitness(witness string, from uint64, limit int) ([]uint64, error) {
+	prefix := witnessPrefix(witness)
+	iter := b.db.Iterate(prefix, uint64Bytes(from), nil)
+	defer iter.Release()
+
+	numbers := make([]uint64, 0)
+	for (limit <= 0 || len(numbers) < limit) && iter.Next() {
+		key := iter.Key()
+		numbers = append(numbers, binary.BigEndian.Uint64(key[len(prefix):]))
+	}
+	return numbers, iter.Error()
+}
+
+// GetBlockNumberByTime returns the number of the last block produced at or before time slot
+func (b *ChainImpl) GetBlockNumberByTime(slot int64) (uint64, error) {
+	if slot < 0 {
+		return 0, ErrIndexNotFound
+	}
+	// look back through windows of doubling size, missed slots leave gaps in the index
+	end := uint64Bytes(uint64(slot) + 1)
+	for window := int64(64); ; window *= 2 {
+		start := slot - window
+		if start < 0 {
+			start = 0
+		}
+		iter := b.db.Iterate(timeIndexPrefix, uint64Bytes(uint64(start)), end)
+		var number []byte
+		for iter.Next() {
+			number = db.CopyBytes(iter.Value())
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return 0, err
+		}
+		if number != nil {
+			return binary.BigEndian.Uint64(number), nil
+		}
+		if start == 0 {
+			return 0, ErrIndexNotFound
+		}
+		end = uint64Bytes(uint64(start))
+	}
+}
+
+// GetTxLocation returns the number of the block that contains the tx and its position in the block
+func (b *ChainImpl) GetTxLocation(txHash []byte) (uint64, int, error) {
+	loc, err := b.db.Get(txIndexKey(txHash))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(loc) != 16 {
+		return 0, 0, ErrIndexNotFound
+	}
+	return binary.BigEndian.Uint64(loc[:8]), int(binary.BigEndian.Uint64(loc[8:])), nil
+}
+
+// RebuildIndexes writes the index entries of every stored block, it returns the
+// number of blocks indexed
+func (b *ChainImpl) RebuildIndexes() (uint64, error) {
+	stream := b.Range(0, b.length)
+	batch := b.db.NewBatch()
+	var count uint64
+	for stream.Next() {
+		blk, err := stream.Block()
+		if err != nil {
+			return count, err
+		}
+		if err := putIndexes(batch, blk); err != nil {
+			return count, fmt.Errorf("failed to index block %v: %v", stream.Number(), err)
+		}
+		count++
+		if count%rebuildBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				return count, err
+			}
+			batch = b.db.NewBatch()
+			log.Log.I("[block] indexed %v blocks", count)
+		}
+	}
+	if err := stream.Error(); err != nil {
+		return count, err
+	}
+	return count, batch.Write()
+}
@@ -1,12 +1,20 @@
 // Package block 是区块和区块链的结构体定义和操作方法
 package block
 
-import "github.com/iost-official/Go-IOS-Protocol/core/tx"
+import (
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+)
 
 //go:generate mockgen -destination ../mocks/mock_blockchain.go -package core_mock github.com/iost-official/Go-IOS-Protocol/core/block Chain
 
 type Chain interface {
 	Push(block *Block) error
+	PushWithPatch(block *Block, patch state.Patch) error
+	GetStatePatch(blockHash []byte) (*state.Patch, error)
+	GetReceipts(blockHash []byte) ([]*Receipt, error)
+	GetReceipt(txHash []byte) (*Receipt, error)
+	Rollback(toHeight uint64) error
 	Length() uint64
 	CheckLength() error
 	Top() *Block // 语法糖
@@ -18,7 +26,14 @@ type Chain interface {
 	HasTx(tx *tx.Tx) (bool, error)
 	GetTx(hash []byte) (*tx.Tx, error)
 
+	GetBlockNumbersByWitness(witness string, from uint64, limit int) ([]uint64, error)
+	GetBlockNumberByTime(slot int64) (uint64, error)
+	GetTxLocation(txHash []byte) (uint64, int, error)
+
 	Iterator() ChainIterator
+	ForwardIterator(from uint64) ChainIterator
+	ReverseIterator(from uint64) ChainIterator
+	Range(from, to uint64) BlockStream
 }
 
 type ChainIterator interface {
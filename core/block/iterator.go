@@ -0,0 +1,145 @@
+package block
+
+import (
+	"fmt"
+)
+
+// BlockStream walks persisted blocks in ascending number order, a block is only
+// decoded when Block is called so callers that need hashes or raw bytes skip the decode
+type BlockStream interface {
+	Next() bool
+	Number() uint64
+	Hash() []byte
+	Bytes() []byte
+	Block() (*Block, error)
+	Error() error
+}
+
+type chainRange struct {
+	chain *ChainImpl
+	next  uint64
+	to    uint64
+
+	number uint64
+	hash   []byte
+	raw    []byte
+	block  *Block
+	err    error
+}
+
+// Range streams the blocks numbered [from, to), to is clipped to the chain length
+func (b *ChainImpl) Range(from, to uint64) BlockStream {
+	if to > b.length {
+		to = b.length
+	}
+	return &chainRange{chain: b, next: from, to: to}
+}
+
+func (r *chainRange) Next() bool {
+	if r.err != nil || r.next >= r.to {
+		return false
+	}
+	number := r.next
+	hash, err := r.chain.db.Get(append(blockNumberPrefix, r.chain.getLengthBytes(number)...))
+	if err != nil || len(hash) == 0 {
+		r.err = fmt.Errorf("block hash of number %v not found", number)
+		return false
+	}
+	raw, err := r.chain.db.Get(append(blockPrefix, hash...))
+	if err != nil || len(raw) == 0 {
+		r.err = fmt.Errorf("block %v not found", number)
+		return false
+	}
+	r.number, r.hash, r.raw, r.block = number, hash, raw, nil
+	r.next++
+	return true
+}
+
+func (r *chainRange) Number() uint64 {
+	return r.number
+}
+
+func (r *chainRange) Hash() []byte {
+	return r.hash
+}
+
+func (r *chainRange) Bytes() []byte {
+	return r.raw
+}
+
+func (r *chainRange) Block() (*Block, error) {
+	if r.block != nil {
+		return r.block, nil
+	}
+	if r.raw == nil {
+		return nil, fmt.Errorf("no current block")
+	}
+	blk := new(Block)
+	if err := blk.Decode(r.raw); err != nil {
+		return nil, fmt.Errorf("failed to decode block %v: %v", r.number, err)
+	}
+	r.block = blk
+	return blk, nil
+}
+
+func (r *chainRange) Error() error {
+	return r.err
+}
+
+type forwardIterator struct {
+	stream BlockStream
+}
+
+func (fi *forwardIterator) Next() *Block {
+	if !fi.stream.Next() {
+		return nil
+	}
+	blk, err := fi.stream.Block()
+	if err != nil {
+		return nil
+	}
+	return blk
+}
+
+type reverseIterator struct {
+	chain *ChainImpl
+	next  uint64
+	done  bool
+}
+
+func (ri *reverseIterator) Next() *Block {
+	if ri.done {
+		return nil
+	}
+	blk := ri.chain.GetBlockByNumber(ri.next)
+	if blk == nil || ri.next == 0 {
+		ri.done = true
+		return blk
+	}
+	ri.next--
+	return blk
+}
+
+// ForwardIterator walks from block number from up to the current top
+func (b *ChainImpl) ForwardIterator(from uint64) ChainIterator {
+	return &forwardIterator{stream: b.Range(from, b.length)}
+}
+
+// ReverseIterator walks from block number from down to the genesis block
+func (b *ChainImpl) ReverseIterator(from uint64) ChainIterator {
+	if b.length == 0 {
+		return &reverseIterator{chain: b, done: true}
+	}
+	if from >= b.length {
+		from = b.length - 1
+	}
+	return &reverseIterator{chain: b, next: from}
+}
+
+// Iterator walks the chain from the top block down to the genesis block
+func (b *ChainImpl) Iterator() ChainIterator {
+	if b.length == 0 {
+		return &reverseIterator{chain: b, done: true}
+	}
+	return b.ReverseIterator(b.length - 1)
+}
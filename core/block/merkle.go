@@ -0,0 +1,156 @@
+package block
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/iost-official/Go-IOS-Protocol/common"
+)
+
+// The transaction tree of a block is a binary Merkle tree over the tx hashes in
+// block order. Leaves and inner nodes are hashed with distinct prefixes so a leaf
+// can never be passed off as an inner node, a node without a sibling on its level
+// is promoted unchanged. The root of the tree is BlockHead.TreeHash of version 2
+// heads, the tree hash of older heads can not prove the inclusion of a tx.
+
+var (
+	merkleLeafPrefix = []byte{0x00}
+	merkleNodePrefix = []byte{0x01}
+
+	ErrTxNotInBlock = errors.New("tx not in block")
+	ErrNoTxTree     = errors.New("block head version has no tx tree")
+)
+
+func merkleLeaf(hash []byte) []byte {
+	return common.Sha256(append(append([]byte{}, merkleLeafPrefix...), hash...))
+}
+
+func merkleNode(left, right []byte) []byte {
+	buf := make([]byte, 0, len(merkleNodePrefix)+len(left)+len(right))
+	buf = append(buf, merkleNodePrefix...)
+	buf = append(buf, left...)
+	return common.Sha256(append(buf, right...))
+}
+
+// MerkleTree keeps every level of the tree, levels[0] are the leaves and the
+// last level holds the root only
+type MerkleTree struct {
+	levels [][][]byte
+}
+
+// NewMerkleTree builds the tree over hashes, an empty tree has the sha256 of nothing as root
+func NewMerkleTree(hashes [][]byte) *MerkleTree {
+	leaves := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		leaves = append(leaves, merkleLeaf(h))
+	}
+	t := &MerkleTree{levels: [][][]byte{leaves}}
+	for level := leaves; len(level) > 1; {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, merkleNode(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t
+}
+
+// Root returns the root hash of the tree
+func (t *MerkleTree) Root() []byte {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		return common.Sha256(nil)
+	}
+	return top[0]
+}
+
+// Proof returns the path from leaf index to the root
+func (t *MerkleTree) Proof(index int) (*TxProof, error) {
+	if index < 0 || index >= len(t.levels[0]) {
+		return nil, ErrTxNotInBlock
+	}
+	proof := &TxProof{Index: int64(index), Count: int64(len(t.levels[0]))}
+	i := index
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := i ^ 1
+		if sibling < len(level) {
+			proof.Siblings = append(proof.Siblings, level[sibling])
+		}
+		i /= 2
+	}
+	return proof, nil
+}
+
+// TxProof shows that a tx is at position Index of a block with Count txs
+type TxProof struct {
+	Index    int64
+	Count    int64
+	Siblings [][]byte
+}
+
+// Root recomputes the tree root from txHash and the proof
+func (p *TxProof) Root(txHash []byte) ([]byte, error) {
+	if p.Index < 0 || p.Index >= p.Count {
+		return nil, errors.New("proof index out of range")
+	}
+	hash := merkleLeaf(txHash)
+	i, n, s := p.Index, p.Count, 0
+	for n > 1 {
+		sibling := i ^ 1
+		if sibling < n {
+			if s >= len(p.Siblings) {
+				return nil, errors.New("proof too short")
+			}
+			if i%2 == 0 {
+				hash = merkleNode(hash, p.Siblings[s])
+			} else {
+				hash = merkleNode(p.Siblings[s], hash)
+			}
+			s++
+		}
+		i /= 2
+		n = (n + 1) / 2
+	}
+	if s != len(p.Siblings) {
+		return nil, errors.New("proof too long")
+	}
+	return hash, nil
+}
+
+// VerifyTxProof reports whether proof shows that txHash is included in the block of head
+func VerifyTxProof(head *BlockHead, txHash []byte, proof *TxProof) bool {
+	if !head.Extended() {
+		return false
+	}
+	root, err := proof.Root(txHash)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(root, head.TreeHash)
+}
+
+func (d *Block) txTree() *MerkleTree {
+	hashes := make([][]byte, 0, len(d.Content))
+	for i := range d.Content {
+		hashes = append(hashes, d.Content[i].Hash())
+	}
+	return NewMerkleTree(hashes)
+}
+
+// GetTxProof returns the inclusion proof of the tx with hash txHash
+func (d *Block) GetTxProof(txHash []byte) (*TxProof, error) {
+	if !d.Head.Extended() {
+		return nil, ErrNoTxTree
+	}
+	for i := range d.Content {
+		if bytes.Equal(d.Content[i].Hash(), txHash) {
+			return d.txTree().Proof(i)
+		}
+	}
+	return nil, ErrTxNotInBlock
+}
@@ -0,0 +1,62 @@
+package block
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/iost-official/Go-IOS-Protocol/common"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMerkleTree(t *testing.T) {
+	Convey("Test of MerkleTree", t, func() {
+		hashes := make([][]byte, 0)
+		for i := 0; i < 7; i++ {
+			hashes = append(hashes, common.Sha256([]byte(strconv.Itoa(i))))
+		}
+
+		Convey("root", func() {
+			So(NewMerkleTree(nil).Root(), ShouldResemble, common.Sha256(nil))
+			So(NewMerkleTree(hashes[:1]).Root(), ShouldResemble, merkleLeaf(hashes[0]))
+			So(NewMerkleTree(hashes[:2]).Root(), ShouldResemble, merkleNode(merkleLeaf(hashes[0]), merkleLeaf(hashes[1])))
+			So(NewMerkleTree(hashes).Root(), ShouldNotResemble, NewMerkleTree(hashes[:6]).Root())
+		})
+
+		Convey("proof", func() {
+			for n := 1; n <= len(hashes); n++ {
+				tree := NewMerkleTree(hashes[:n])
+				head := BlockHead{Version: Version2, TreeHash: tree.Root()}
+				for i := 0; i < n; i++ {
+					proof, err := tree.Proof(i)
+					So(err, ShouldBeNil)
+					So(VerifyTxProof(&head, hashes[i], proof), ShouldBeTrue)
+					So(VerifyTxProof(&head, hashes[(i+1)%len(hashes)], proof), ShouldBeFalse)
+				}
+			}
+			_, err := NewMerkleTree(hashes).Proof(len(hashes))
+			So(err, ShouldEqual, ErrTxNotInBlock)
+		})
+
+		Convey("tampered proof", func() {
+			tree := NewMerkleTree(hashes)
+			head := BlockHead{Version: Version2, TreeHash: tree.Root()}
+			proof, err := tree.Proof(3)
+			So(err, ShouldBeNil)
+			proof.Index = 2
+			So(VerifyTxProof(&head, hashes[3], proof), ShouldBeFalse)
+			proof.Index = 3
+			proof.Siblings = proof.Siblings[1:]
+			So(VerifyTxProof(&head, hashes[3], proof), ShouldBeFalse)
+		})
+
+		Convey("legacy head", func() {
+			tree := NewMerkleTree(hashes)
+			head := BlockHead{Version: Version0, TreeHash: tree.Root()}
+			proof, err := tree.Proof(3)
+			So(err, ShouldBeNil)
+			So(VerifyTxProof(&head, hashes[3], proof), ShouldBeFalse)
+			_, err = (&Block{Head: head}).GetTxProof(hashes[3])
+			So(err, ShouldEqual, ErrNoTxTree)
+		})
+	})
+}
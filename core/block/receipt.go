@@ -0,0 +1,136 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/iost-official/Go-IOS-Protocol/common"
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+)
+
+type ReceiptStatus int64
+
+const (
+	ReceiptSuccess ReceiptStatus = iota
+	ReceiptFailed
+)
+
+// Receipt is the result of a tx in a block, a failed tx pays its fee but leaves no other state change
+type Receipt struct {
+	TxHash  []byte
+	Status  ReceiptStatus
+	GasUsed uint64
+	Fee     *state.VDecimal
+	Logs    []string
+	Error   string
+}
+
+func (r *Receipt) Encode() []byte {
+	logs := make([][]byte, 0, len(r.Logs))
+	for _, l := range r.Logs {
+		logs = append(logs, []byte(l))
+	}
+	fee := ""
+	if r.Fee != nil {
+		fee = r.Fee.String()
+	}
+	rr := ReceiptRaw{
+		TxHash:  r.TxHash,
+		Status:  int64(r.Status),
+		GasUsed: int64(r.GasUsed),
+		Fee:     fee,
+		Logs:    logs,
+		Error:   r.Error,
+	}
+	b, err := rr.Marshal(nil)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (r *Receipt) Decode(bin []byte) (err error) {
+	var rr ReceiptRaw
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+
+	_, err = rr.Unmarshal(bin)
+	if err != nil {
+		return err
+	}
+	r.TxHash = rr.TxHash
+	r.Status = ReceiptStatus(rr.Status)
+	r.GasUsed = uint64(rr.GasUsed)
+	r.Fee = nil
+	if rr.Fee != "" {
+		r.Fee, err = state.ParseDecimal(rr.Fee)
+		if err != nil {
+			return err
+		}
+	}
+	r.Logs = make([]string, 0, len(rr.Logs))
+	for _, l := range rr.Logs {
+		r.Logs = append(r.Logs, string(l))
+	}
+	r.Error = rr.Error
+	return nil
+}
+
+func (r *Receipt) Hash() []byte {
+	return common.Sha256(r.Encode())
+}
+
+// ReceiptsRoot is the Merkle root over the receipt hashes, it is BlockHead.ReceiptsRoot
+func ReceiptsRoot(receipts []*Receipt) []byte {
+	hashes := make([][]byte, 0, len(receipts))
+	for _, r := range receipts {
+		hashes = append(hashes, r.Hash())
+	}
+	return NewMerkleTree(hashes).Root()
+}
+
+// ReceiptsGasUsed is the gas used by all the receipts, it is BlockHead.GasUsed
+func ReceiptsGasUsed(receipts []*Receipt) uint64 {
+	var gas uint64
+	for _, r := range receipts {
+		gas += r.GasUsed
+	}
+	return gas
+}
+
+func encodeReceipts(receipts []*Receipt) []byte {
+	rr := ReceiptsRaw{Receipts: make([][]byte, 0, len(receipts))}
+	for _, r := range receipts {
+		rr.Receipts = append(rr.Receipts, r.Encode())
+	}
+	b, err := rr.Marshal(nil)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func decodeReceipts(bin []byte) (receipts []*Receipt, err error) {
+	var rr ReceiptsRaw
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+
+	_, err = rr.Unmarshal(bin)
+	if err != nil {
+		return nil, err
+	}
+	receipts = make([]*Receipt, 0, len(rr.Receipts))
+	for _, b := range rr.Receipts {
+		var r Receipt
+		if err := r.Decode(b); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, &r)
+	}
+	return receipts, nil
+}
@@ -0,0 +1,44 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReceipt(t *testing.T) {
+	Convey("Test of Receipt", t, func() {
+		r := Receipt{
+			TxHash:  []byte("tx hash"),
+			Status:  ReceiptFailed,
+			GasUsed: 1234,
+			Fee:     state.MustParseDecimal("12.34"),
+			Logs:    []string{"a", "bc"},
+			Error:   "gas overflow",
+		}
+
+		Convey("encode and decode", func() {
+			var r2 Receipt
+			So(r2.Decode(r.Encode()), ShouldBeNil)
+			So(r2.TxHash, ShouldResemble, r.TxHash)
+			So(r2.Status, ShouldEqual, r.Status)
+			So(r2.GasUsed, ShouldEqual, r.GasUsed)
+			So(r2.Fee.String(), ShouldEqual, r.Fee.String())
+			So(r2.Logs, ShouldResemble, r.Logs)
+			So(r2.Error, ShouldEqual, r.Error)
+		})
+
+		Convey("receipts list", func() {
+			r2 := r
+			r2.Status = ReceiptSuccess
+			r2.Error = ""
+			receipts, err := decodeReceipts(encodeReceipts([]*Receipt{&r, &r2}))
+			So(err, ShouldBeNil)
+			So(len(receipts), ShouldEqual, 2)
+			So(receipts[1].Hash(), ShouldResemble, r2.Hash())
+			So(ReceiptsRoot(receipts), ShouldResemble, ReceiptsRoot([]*Receipt{&r, &r2}))
+			So(ReceiptsRoot(receipts[:1]), ShouldNotResemble, ReceiptsRoot(receipts))
+		})
+	})
+}
@@ -13,6 +13,1278 @@ var (
 )
 
 type BlockHead struct {
+	Version      int64
+	ParentHash   []byte
+	TreeHash     []byte
+	Info         []byte
+	Number       int64
+	Witness      string
+	Signature    []byte
+	Time         int64
+	StateRoot    []byte
+	ReceiptsRoot []byte
+	GasUsed      int64
+	TxCount      int64
+}
+
+func (d *BlockHead) Size() (s uint64) {
+
+	{
+		l := uint64(len(d.ParentHash))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.TreeHash))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.Info))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.Witness))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.Signature))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.StateRoot))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.ReceiptsRoot))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	s += 40
+	return
+}
+func (d *BlockHead) Marshal(buf []byte) ([]byte, error) {
+	size := d.Size()
+	{
+		if uint64(cap(buf)) >= size {
+			buf = buf[:size]
+		} else {
+			buf = make([]byte, size)
+		}
+	}
+	i := uint64(0)
+
+	{
+
+		buf[0+0] = byte(d.Version >> 0)
+
+		buf[1+0] = byte(d.Version >> 8)
+
+		buf[2+0] = byte(d.Version >> 16)
+
+		buf[3+0] = byte(d.Version >> 24)
+
+		buf[4+0] = byte(d.Version >> 32)
+
+		buf[5+0] = byte(d.Version >> 40)
+
+		buf[6+0] = byte(d.Version >> 48)
+
+		buf[7+0] = byte(d.Version >> 56)
+
+	}
+	{
+		l := uint64(len(d.ParentHash))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+8] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+8] = byte(t)
+			i++
+
+		}
+		copy(buf[i+8:], d.ParentHash)
+		i += l
+	}
+	{
+		l := uint64(len(d.TreeHash))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+8] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+8] = byte(t)
+			i++
+
+		}
+		copy(buf[i+8:], d.TreeHash)
+		i += l
+	}
+	{
+		l := uint64(len(d.Info))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+8] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+8] = byte(t)
+			i++
+
+		}
+		copy(buf[i+8:], d.Info)
+		i += l
+	}
+	{
+
+		buf[i+0+8] = byte(d.Number >> 0)
+
+		buf[i+1+8] = byte(d.Number >> 8)
+
+		buf[i+2+8] = byte(d.Number >> 16)
+
+		buf[i+3+8] = byte(d.Number >> 24)
+
+		buf[i+4+8] = byte(d.Number >> 32)
+
+		buf[i+5+8] = byte(d.Number >> 40)
+
+		buf[i+6+8] = byte(d.Number >> 48)
+
+		buf[i+7+8] = byte(d.Number >> 56)
+
+	}
+	{
+		l := uint64(len(d.Witness))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+16] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+16] = byte(t)
+			i++
+
+		}
+		copy(buf[i+16:], d.Witness)
+		i += l
+	}
+	{
+		l := uint64(len(d.Signature))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+16] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+16] = byte(t)
+			i++
+
+		}
+		copy(buf[i+16:], d.Signature)
+		i += l
+	}
+	{
+
+		buf[i+0+16] = byte(d.Time >> 0)
+
+		buf[i+1+16] = byte(d.Time >> 8)
+
+		buf[i+2+16] = byte(d.Time >> 16)
+
+		buf[i+3+16] = byte(d.Time >> 24)
+
+		buf[i+4+16] = byte(d.Time >> 32)
+
+		buf[i+5+16] = byte(d.Time >> 40)
+
+		buf[i+6+16] = byte(d.Time >> 48)
+
+		buf[i+7+16] = byte(d.Time >> 56)
+
+	}
+	{
+		l := uint64(len(d.StateRoot))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+24] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+24] = byte(t)
+			i++
+
+		}
+		copy(buf[i+24:], d.StateRoot)
+		i += l
+	}
+	{
+		l := uint64(len(d.ReceiptsRoot))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+24] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+24] = byte(t)
+			i++
+
+		}
+		copy(buf[i+24:], d.ReceiptsRoot)
+		i += l
+	}
+	{
+
+		buf[i+0+24] = byte(d.GasUsed >> 0)
+
+		buf[i+1+24] = byte(d.GasUsed >> 8)
+
+		buf[i+2+24] = byte(d.GasUsed >> 16)
+
+		buf[i+3+24] = byte(d.GasUsed >> 24)
+
+		buf[i+4+24] = byte(d.GasUsed >> 32)
+
+		buf[i+5+24] = byte(d.GasUsed >> 40)
+
+		buf[i+6+24] = byte(d.GasUsed >> 48)
+
+		buf[i+7+24] = byte(d.GasUsed >> 56)
+
+	}
+	{
+
+		buf[i+0+32] = byte(d.TxCount >> 0)
+
+		buf[i+1+32] = byte(d.TxCount >> 8)
+
+		buf[i+2+32] = byte(d.TxCount >> 16)
+
+		buf[i+3+32] = byte(d.TxCount >> 24)
+
+		buf[i+4+32] = byte(d.TxCount >> 32)
+
+		buf[i+5+32] = byte(d.TxCount >> 40)
+
+		buf[i+6+32] = byte(d.TxCount >> 48)
+
+		buf[i+7+32] = byte(d.TxCount >> 56)
+
+	}
+	return buf[:i+40], nil
+}
+
+func (d *BlockHead) Unmarshal(buf []byte) (uint64, error) {
+	i := uint64(0)
+
+	{
+
+		d.Version = 0 | (int64(buf[i+0+0]) << 0) | (int64(buf[i+1+0]) << 8) | (int64(buf[i+2+0]) << 16) | (int64(buf[i+3+0]) << 24) | (int64(buf[i+4+0]) << 32) | (int64(buf[i+5+0]) << 40) | (int64(buf[i+6+0]) << 48) | (int64(buf[i+7+0]) << 56)
+
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+8] & 0x7F)
+			for buf[i+8]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+8]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.ParentHash)) >= l {
+			d.ParentHash = d.ParentHash[:l]
+		} else {
+			d.ParentHash = make([]byte, l)
+		}
+		copy(d.ParentHash, buf[i+8:])
+		i += l
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+8] & 0x7F)
+			for buf[i+8]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+8]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.TreeHash)) >= l {
+			d.TreeHash = d.TreeHash[:l]
+		} else {
+			d.TreeHash = make([]byte, l)
+		}
+		copy(d.TreeHash, buf[i+8:])
+		i += l
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+8] & 0x7F)
+			for buf[i+8]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+8]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.Info)) >= l {
+			d.Info = d.Info[:l]
+		} else {
+			d.Info = make([]byte, l)
+		}
+		copy(d.Info, buf[i+8:])
+		i += l
+	}
+	{
+
+		d.Number = 0 | (int64(buf[i+0+8]) << 0) | (int64(buf[i+1+8]) << 8) | (int64(buf[i+2+8]) << 16) | (int64(buf[i+3+8]) << 24) | (int64(buf[i+4+8]) << 32) | (int64(buf[i+5+8]) << 40) | (int64(buf[i+6+8]) << 48) | (int64(buf[i+7+8]) << 56)
+
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+16] & 0x7F)
+			for buf[i+16]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+16]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		d.Witness = string(buf[i+16 : i+16+l])
+		i += l
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+16] & 0x7F)
+			for buf[i+16]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+16]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.Signature)) >= l {
+			d.Signature = d.Signature[:l]
+		} else {
+			d.Signature = make([]byte, l)
+		}
+		copy(d.Signature, buf[i+16:])
+		i += l
+	}
+	{
+
+		d.Time = 0 | (int64(buf[i+0+16]) << 0) | (int64(buf[i+1+16]) << 8) | (int64(buf[i+2+16]) << 16) | (int64(buf[i+3+16]) << 24) | (int64(buf[i+4+16]) << 32) | (int64(buf[i+5+16]) << 40) | (int64(buf[i+6+16]) << 48) | (int64(buf[i+7+16]) << 56)
+
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+24] & 0x7F)
+			for buf[i+24]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+24]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.StateRoot)) >= l {
+			d.StateRoot = d.StateRoot[:l]
+		} else {
+			d.StateRoot = make([]byte, l)
+		}
+		copy(d.StateRoot, buf[i+24:])
+		i += l
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+24] & 0x7F)
+			for buf[i+24]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+24]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.ReceiptsRoot)) >= l {
+			d.ReceiptsRoot = d.ReceiptsRoot[:l]
+		} else {
+			d.ReceiptsRoot = make([]byte, l)
+		}
+		copy(d.ReceiptsRoot, buf[i+24:])
+		i += l
+	}
+	{
+
+		d.GasUsed = 0 | (int64(buf[i+0+24]) << 0) | (int64(buf[i+1+24]) << 8) | (int64(buf[i+2+24]) << 16) | (int64(buf[i+3+24]) << 24) | (int64(buf[i+4+24]) << 32) | (int64(buf[i+5+24]) << 40) | (int64(buf[i+6+24]) << 48) | (int64(buf[i+7+24]) << 56)
+
+	}
+	{
+
+		d.TxCount = 0 | (int64(buf[i+0+32]) << 0) | (int64(buf[i+1+32]) << 8) | (int64(buf[i+2+32]) << 16) | (int64(buf[i+3+32]) << 24) | (int64(buf[i+4+32]) << 32) | (int64(buf[i+5+32]) << 40) | (int64(buf[i+6+32]) << 48) | (int64(buf[i+7+32]) << 56)
+
+	}
+	return i + 40, nil
+}
+
+type BlockRaw struct {
+	Head    BlockHead
+	Content [][]byte
+}
+
+func (d *BlockRaw) Size() (s uint64) {
+
+	{
+		s += d.Head.Size()
+	}
+	{
+		l := uint64(len(d.Content))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+
+		for k0 := range d.Content {
+
+			{
+				l := uint64(len(d.Content[k0]))
+
+				{
+
+					t := l
+					for t >= 0x80 {
+						t >>= 7
+						s++
+					}
+					s++
+
+				}
+				s += l
+			}
+
+		}
+
+	}
+	return
+}
+func (d *BlockRaw) Marshal(buf []byte) ([]byte, error) {
+	size := d.Size()
+	{
+		if uint64(cap(buf)) >= size {
+			buf = buf[:size]
+		} else {
+			buf = make([]byte, size)
+		}
+	}
+	i := uint64(0)
+
+	{
+		nbuf, err := d.Head.Marshal(buf[0:])
+		if err != nil {
+			return nil, err
+		}
+		i += uint64(len(nbuf))
+	}
+	{
+		l := uint64(len(d.Content))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+0] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+0] = byte(t)
+			i++
+
+		}
+		for k0 := range d.Content {
+
+			{
+				l := uint64(len(d.Content[k0]))
+
+				{
+
+					t := uint64(l)
+
+					for t >= 0x80 {
+						buf[i+0] = byte(t) | 0x80
+						t >>= 7
+						i++
+					}
+					buf[i+0] = byte(t)
+					i++
+
+				}
+				copy(buf[i+0:], d.Content[k0])
+				i += l
+			}
+
+		}
+	}
+	return buf[:i+0], nil
+}
+
+func (d *BlockRaw) Unmarshal(buf []byte) (uint64, error) {
+	i := uint64(0)
+
+	{
+		ni, err := d.Head.Unmarshal(buf[i+0:])
+		if err != nil {
+			return 0, err
+		}
+		i += ni
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+0] & 0x7F)
+			for buf[i+0]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+0]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.Content)) >= l {
+			d.Content = d.Content[:l]
+		} else {
+			d.Content = make([][]byte, l)
+		}
+		for k0 := range d.Content {
+
+			{
+				l := uint64(0)
+
+				{
+
+					bs := uint8(7)
+					t := uint64(buf[i+0] & 0x7F)
+					for buf[i+0]&0x80 == 0x80 {
+						i++
+						t |= uint64(buf[i+0]&0x7F) << bs
+						bs += 7
+					}
+					i++
+
+					l = t
+
+				}
+				if uint64(cap(d.Content[k0])) >= l {
+					d.Content[k0] = d.Content[k0][:l]
+				} else {
+					d.Content[k0] = make([]byte, l)
+				}
+				copy(d.Content[k0], buf[i+0:])
+				i += l
+			}
+
+		}
+	}
+	return i + 0, nil
+}
+
+type ReceiptRaw struct {
+	TxHash  []byte
+	Status  int64
+	GasUsed int64
+	Fee     string
+	Logs    [][]byte
+	Error   string
+}
+
+func (d *ReceiptRaw) Size() (s uint64) {
+
+	{
+		l := uint64(len(d.TxHash))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.Fee))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	{
+		l := uint64(len(d.Logs))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+
+		for k0 := range d.Logs {
+
+			{
+				l := uint64(len(d.Logs[k0]))
+
+				{
+
+					t := l
+					for t >= 0x80 {
+						t >>= 7
+						s++
+					}
+					s++
+
+				}
+				s += l
+			}
+
+		}
+
+	}
+	{
+		l := uint64(len(d.Error))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+		s += l
+	}
+	s += 16
+	return
+}
+func (d *ReceiptRaw) Marshal(buf []byte) ([]byte, error) {
+	size := d.Size()
+	{
+		if uint64(cap(buf)) >= size {
+			buf = buf[:size]
+		} else {
+			buf = make([]byte, size)
+		}
+	}
+	i := uint64(0)
+
+	{
+		l := uint64(len(d.TxHash))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+0] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+0] = byte(t)
+			i++
+
+		}
+		copy(buf[i+0:], d.TxHash)
+		i += l
+	}
+	{
+
+		buf[i+0+0] = byte(d.Status >> 0)
+
+		buf[i+1+0] = byte(d.Status >> 8)
+
+		buf[i+2+0] = byte(d.Status >> 16)
+
+		buf[i+3+0] = byte(d.Status >> 24)
+
+		buf[i+4+0] = byte(d.Status >> 32)
+
+		buf[i+5+0] = byte(d.Status >> 40)
+
+		buf[i+6+0] = byte(d.Status >> 48)
+
+		buf[i+7+0] = byte(d.Status >> 56)
+
+	}
+	{
+
+		buf[i+0+8] = byte(d.GasUsed >> 0)
+
+		buf[i+1+8] = byte(d.GasUsed >> 8)
+
+		buf[i+2+8] = byte(d.GasUsed >> 16)
+
+		buf[i+3+8] = byte(d.GasUsed >> 24)
+
+		buf[i+4+8] = byte(d.GasUsed >> 32)
+
+		buf[i+5+8] = byte(d.GasUsed >> 40)
+
+		buf[i+6+8] = byte(d.GasUsed >> 48)
+
+		buf[i+7+8] = byte(d.GasUsed >> 56)
+
+	}
+	{
+		l := uint64(len(d.Fee))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+16] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+16] = byte(t)
+			i++
+
+		}
+		copy(buf[i+16:], d.Fee)
+		i += l
+	}
+	{
+		l := uint64(len(d.Logs))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+16] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+16] = byte(t)
+			i++
+
+		}
+		for k0 := range d.Logs {
+
+			{
+				l := uint64(len(d.Logs[k0]))
+
+				{
+
+					t := uint64(l)
+
+					for t >= 0x80 {
+						buf[i+16] = byte(t) | 0x80
+						t >>= 7
+						i++
+					}
+					buf[i+16] = byte(t)
+					i++
+
+				}
+				copy(buf[i+16:], d.Logs[k0])
+				i += l
+			}
+
+		}
+	}
+	{
+		l := uint64(len(d.Error))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+16] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+16] = byte(t)
+			i++
+
+		}
+		copy(buf[i+16:], d.Error)
+		i += l
+	}
+	return buf[:i+16], nil
+}
+
+func (d *ReceiptRaw) Unmarshal(buf []byte) (uint64, error) {
+	i := uint64(0)
+
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+0] & 0x7F)
+			for buf[i+0]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+0]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.TxHash)) >= l {
+			d.TxHash = d.TxHash[:l]
+		} else {
+			d.TxHash = make([]byte, l)
+		}
+		copy(d.TxHash, buf[i+0:])
+		i += l
+	}
+	{
+
+		d.Status = 0 | (int64(buf[i+0+0]) << 0) | (int64(buf[i+1+0]) << 8) | (int64(buf[i+2+0]) << 16) | (int64(buf[i+3+0]) << 24) | (int64(buf[i+4+0]) << 32) | (int64(buf[i+5+0]) << 40) | (int64(buf[i+6+0]) << 48) | (int64(buf[i+7+0]) << 56)
+
+	}
+	{
+
+		d.GasUsed = 0 | (int64(buf[i+0+8]) << 0) | (int64(buf[i+1+8]) << 8) | (int64(buf[i+2+8]) << 16) | (int64(buf[i+3+8]) << 24) | (int64(buf[i+4+8]) << 32) | (int64(buf[i+5+8]) << 40) | (int64(buf[i+6+8]) << 48) | (int64(buf[i+7+8]) << 56)
+
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+16] & 0x7F)
+			for buf[i+16]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+16]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		d.Fee = string(buf[i+16 : i+16+l])
+		i += l
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+16] & 0x7F)
+			for buf[i+16]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+16]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.Logs)) >= l {
+			d.Logs = d.Logs[:l]
+		} else {
+			d.Logs = make([][]byte, l)
+		}
+		for k0 := range d.Logs {
+
+			{
+				l := uint64(0)
+
+				{
+
+					bs := uint8(7)
+					t := uint64(buf[i+16] & 0x7F)
+					for buf[i+16]&0x80 == 0x80 {
+						i++
+						t |= uint64(buf[i+16]&0x7F) << bs
+						bs += 7
+					}
+					i++
+
+					l = t
+
+				}
+				if uint64(cap(d.Logs[k0])) >= l {
+					d.Logs[k0] = d.Logs[k0][:l]
+				} else {
+					d.Logs[k0] = make([]byte, l)
+				}
+				copy(d.Logs[k0], buf[i+16:])
+				i += l
+			}
+
+		}
+	}
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+16] & 0x7F)
+			for buf[i+16]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+16]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		d.Error = string(buf[i+16 : i+16+l])
+		i += l
+	}
+	return i + 16, nil
+}
+
+type ReceiptsRaw struct {
+	Receipts [][]byte
+}
+
+func (d *ReceiptsRaw) Size() (s uint64) {
+
+	{
+		l := uint64(len(d.Receipts))
+
+		{
+
+			t := l
+			for t >= 0x80 {
+				t >>= 7
+				s++
+			}
+			s++
+
+		}
+
+		for k0 := range d.Receipts {
+
+			{
+				l := uint64(len(d.Receipts[k0]))
+
+				{
+
+					t := l
+					for t >= 0x80 {
+						t >>= 7
+						s++
+					}
+					s++
+
+				}
+				s += l
+			}
+
+		}
+
+	}
+	return
+}
+func (d *ReceiptsRaw) Marshal(buf []byte) ([]byte, error) {
+	size := d.Size()
+	{
+		if uint64(cap(buf)) >= size {
+			buf = buf[:size]
+		} else {
+			buf = make([]byte, size)
+		}
+	}
+	i := uint64(0)
+
+	{
+		l := uint64(len(d.Receipts))
+
+		{
+
+			t := uint64(l)
+
+			for t >= 0x80 {
+				buf[i+0] = byte(t) | 0x80
+				t >>= 7
+				i++
+			}
+			buf[i+0] = byte(t)
+			i++
+
+		}
+		for k0 := range d.Receipts {
+
+			{
+				l := uint64(len(d.Receipts[k0]))
+
+				{
+
+					t := uint64(l)
+
+					for t >= 0x80 {
+						buf[i+0] = byte(t) | 0x80
+						t >>= 7
+						i++
+					}
+					buf[i+0] = byte(t)
+					i++
+
+				}
+				copy(buf[i+0:], d.Receipts[k0])
+				i += l
+			}
+
+		}
+	}
+	return buf[:i+0], nil
+}
+
+func (d *ReceiptsRaw) Unmarshal(buf []byte) (uint64, error) {
+	i := uint64(0)
+
+	{
+		l := uint64(0)
+
+		{
+
+			bs := uint8(7)
+			t := uint64(buf[i+0] & 0x7F)
+			for buf[i+0]&0x80 == 0x80 {
+				i++
+				t |= uint64(buf[i+0]&0x7F) << bs
+				bs += 7
+			}
+			i++
+
+			l = t
+
+		}
+		if uint64(cap(d.Receipts)) >= l {
+			d.Receipts = d.Receipts[:l]
+		} else {
+			d.Receipts = make([][]byte, l)
+		}
+		for k0 := range d.Receipts {
+
+			{
+				l := uint64(0)
+
+				{
+
+					bs := uint8(7)
+					t := uint64(buf[i+0] & 0x7F)
+					for buf[i+0]&0x80 == 0x80 {
+						i++
+						t |= uint64(buf[i+0]&0x7F) << bs
+						bs += 7
+					}
+					i++
+
+					l = t
+
+				}
+				if uint64(cap(d.Receipts[k0])) >= l {
+					d.Receipts[k0] = d.Receipts[k0][:l]
+				} else {
+					d.Receipts[k0] = make([]byte, l)
+				}
+				copy(d.Receipts[k0], buf[i+0:])
+				i += l
+			}
+
+		}
+	}
+	return i + 0, nil
+}
+
+type BlockHeadV0 struct {
 	Version    int64
 	ParentHash []byte
 	TreeHash   []byte
@@ -23,7 +1295,7 @@ type BlockHead struct {
 	Time       int64
 }
 
-func (d *BlockHead) Size() (s uint64) {
+func (d *BlockHeadV0) Size() (s uint64) {
 
 	{
 		l := uint64(len(d.ParentHash))
@@ -103,7 +1375,7 @@ func (d *BlockHead) Size() (s uint64) {
 	s += 24
 	return
 }
-func (d *BlockHead) Marshal(buf []byte) ([]byte, error) {
+func (d *BlockHeadV0) Marshal(buf []byte) ([]byte, error) {
 	size := d.Size()
 	{
 		if uint64(cap(buf)) >= size {
@@ -269,7 +1541,7 @@ func (d *BlockHead) Marshal(buf []byte) ([]byte, error) {
 	return buf[:i+24], nil
 }
 
-func (d *BlockHead) Unmarshal(buf []byte) (uint64, error) {
+func (d *BlockHeadV0) Unmarshal(buf []byte) (uint64, error) {
 	i := uint64(0)
 
 	{
@@ -410,12 +1682,12 @@ func (d *BlockHead) Unmarshal(buf []byte) (uint64, error) {
 	return i + 24, nil
 }
 
-type BlockRaw struct {
-	Head    BlockHead
+type BlockRawV0 struct {
+	Head    BlockHeadV0
 	Content [][]byte
 }
 
-func (d *BlockRaw) Size() (s uint64) {
+func (d *BlockRawV0) Size() (s uint64) {
 
 	{
 		s += d.Head.Size()
@@ -457,7 +1729,7 @@ func (d *BlockRaw) Size() (s uint64) {
 	}
 	return
 }
-func (d *BlockRaw) Marshal(buf []byte) ([]byte, error) {
+func (d *BlockRawV0) Marshal(buf []byte) ([]byte, error) {
 	size := d.Size()
 	{
 		if uint64(cap(buf)) >= size {
@@ -518,7 +1790,7 @@ func (d *BlockRaw) Marshal(buf []byte) ([]byte, error) {
 	return buf[:i+0], nil
 }
 
-func (d *BlockRaw) Unmarshal(buf []byte) (uint64, error) {
+func (d *BlockRawV0) Unmarshal(buf []byte) (uint64, error) {
 	i := uint64(0)
 
 	{
@@ -0,0 +1,116 @@
+package block
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Block head versions. Versions 0 and 1 share the original head layout and
+// differ in how the block cache decides that a block is final. Version 2
+// extends the head with the state root, the receipts root, the gas used and
+// the tx count of the block, and is final like version 0.
+const (
+	Version0 int64 = iota
+	Version1
+	Version2
+
+	LatestVersion = Version2
+)
+
+// Version2Height is the number of the first block with a version 2 head, the
+// blocks below it keep version 0. A negative height leaves the upgrade unscheduled.
+var Version2Height int64 = -1
+
+// StateRootHeight is the number of the first block whose head must carry the state
+// root of its outcome. Version 2 heads below it leave the state root empty, so the
+// nodes of a running chain can rebuild their state trie in between. It must not come
+// before Version2Height, a negative height makes every version 2 head carry the root.
+var StateRootHeight int64 = -1
+
+var ErrStateRootHeight = errors.New("state root height before version 2 height")
+
+var ErrUnknownVersion = errors.New("unknown block head version")
+
+// Finality tells how the block cache decides that a block is final
+type Finality int
+
+const (
+	// ConfirmFinality makes a block final once enough witnesses built on it
+	ConfirmFinality Finality = iota
+	// DepthFinality makes a block final once enough blocks were built on it
+	DepthFinality
+)
+
+// VersionAt returns the head version of block number
+func VersionAt(number int64) int64 {
+	if Version2Height >= 0 && number >= Version2Height {
+		return Version2
+	}
+	return Version0
+}
+
+// CheckStateRootHeight returns ErrStateRootHeight when StateRootHeight asks for state
+// roots in heads that can not carry one
+func CheckStateRootHeight() error {
+	if StateRootHeight >= 0 && (Version2Height < 0 || StateRootHeight < Version2Height) {
+		return ErrStateRootHeight
+	}
+	return nil
+}
+
+// VersionFinality returns the finality rule of blocks with head version
+func VersionFinality(version int64) Finality {
+	if version == Version1 {
+		return DepthFinality
+	}
+	return ConfirmFinality
+}
+
+// Extended reports whether the head carries the fields added by version 2
+func (d *BlockHead) Extended() bool {
+	return d.Version >= Version2
+}
+
+// StateRootRequired reports whether the head must carry the state root of its block
+func (d *BlockHead) StateRootRequired() bool {
+	return d.Extended() && (StateRootHeight < 0 || d.Number >= StateRootHeight)
+}
+
+// encodedVersion reads the version leading an encoded head or block, both
+// layouts start with it as a little endian int64
+func encodedVersion(bin []byte) (int64, error) {
+	if len(bin) < 8 {
+		return 0, errors.New("block head too short")
+	}
+	version := int64(binary.LittleEndian.Uint64(bin))
+	if version < Version0 || version > LatestVersion {
+		return 0, ErrUnknownVersion
+	}
+	return version, nil
+}
+
+func (d *BlockHead) legacy() BlockHeadV0 {
+	return BlockHeadV0{
+		Version:    d.Version,
+		ParentHash: d.ParentHash,
+		TreeHash:   d.TreeHash,
+		Info:       d.Info,
+		Number:     d.Number,
+		Witness:    d.Witness,
+		Signature:  d.Signature,
+		Time:       d.Time,
+	}
+}
+
+func (h *BlockHeadV0) upgrade() BlockHead {
+	return BlockHead{
+		Version:    h.Version,
+		ParentHash: h.ParentHash,
+		TreeHash:   h.TreeHash,
+		Info:       h.Info,
+		Number:     h.Number,
+		Witness:    h.Witness,
+		Signature:  h.Signature,
+		Time:       h.Time,
+	}
+}
@@ -0,0 +1,143 @@
+package block
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type corpusBlock struct {
+	number int64
+	hash   []byte
+	raw    []byte
+}
+
+func loadCorpus(path string) ([]corpusBlock, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	blocks := make([]corpusBlock, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		var cb corpusBlock
+		if cb.number, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+			return nil, err
+		}
+		if cb.hash, err = hex.DecodeString(fields[1]); err != nil {
+			return nil, err
+		}
+		if cb.raw, err = hex.DecodeString(fields[2]); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, cb)
+	}
+	return blocks, scanner.Err()
+}
+
+func TestBlockVersion(t *testing.T) {
+	Convey("Test of block head versions", t, func() {
+		Convey("old format blocks decode", func() {
+			corpus, err := loadCorpus("testdata/blocks_v0.txt")
+			So(err, ShouldBeNil)
+			So(len(corpus), ShouldBeGreaterThan, 0)
+
+			var parent []byte
+			for _, cb := range corpus {
+				var blk Block
+				So(blk.Decode(cb.raw), ShouldBeNil)
+				So(blk.Head.Number, ShouldEqual, cb.number)
+				So(blk.Head.Extended(), ShouldBeFalse)
+				So(blk.HeadHash(), ShouldResemble, cb.hash)
+				So(blk.Encode(), ShouldResemble, cb.raw)
+				So(blk.CalculateTreeHash(), ShouldResemble, blk.Head.TreeHash)
+				if parent != nil {
+					So(blk.Head.ParentHash, ShouldResemble, parent)
+				}
+				parent = cb.hash
+
+				var head BlockHead
+				So(head.Decode(blk.Head.Encode()), ShouldBeNil)
+				So(head.Hash(), ShouldResemble, cb.hash)
+			}
+		})
+
+		Convey("version 2 heads carry the extended fields", func() {
+			blk := Block{Head: BlockHead{
+				Version:      Version2,
+				ParentHash:   []byte("parent"),
+				Number:       7,
+				Witness:      "witness",
+				Time:         100,
+				StateRoot:    []byte("state root"),
+				ReceiptsRoot: []byte("receipts root"),
+				GasUsed:      1234,
+				TxCount:      0,
+			}}
+			var blk2 Block
+			So(blk2.Decode(blk.Encode()), ShouldBeNil)
+			So(blk2.Head, ShouldResemble, blk.Head)
+
+			var head BlockHead
+			So(head.Decode(blk.Head.Encode()), ShouldBeNil)
+			So(head, ShouldResemble, blk.Head)
+
+			old := blk.Head
+			old.Version = Version0
+			So(head.Decode(old.Encode()), ShouldBeNil)
+			So(head.StateRoot, ShouldBeNil)
+			So(head.GasUsed, ShouldEqual, 0)
+			So(len(old.Encode()), ShouldBeLessThan, len(blk.Head.Encode()))
+		})
+
+		Convey("unknown versions are rejected", func() {
+			head := BlockHead{Version: LatestVersion + 1}
+			bin, err := head.Marshal(nil)
+			So(err, ShouldBeNil)
+			So(head.Decode(bin), ShouldEqual, ErrUnknownVersion)
+			So(head.Decode([]byte{1, 2}), ShouldNotBeNil)
+		})
+
+		Convey("upgrade height", func() {
+			defer func(h int64) { Version2Height = h }(Version2Height)
+			Version2Height = -1
+			So(VersionAt(1000), ShouldEqual, Version0)
+			Version2Height = 10
+			So(VersionAt(9), ShouldEqual, Version0)
+			So(VersionAt(10), ShouldEqual, Version2)
+			So(VersionFinality(Version1), ShouldEqual, DepthFinality)
+			So(VersionFinality(Version2), ShouldEqual, ConfirmFinality)
+		})
+
+		Convey("state root height", func() {
+			defer func(h, r int64) { Version2Height, StateRootHeight = h, r }(Version2Height, StateRootHeight)
+			Version2Height = 10
+			StateRootHeight = -1
+			So(CheckStateRootHeight(), ShouldBeNil)
+			So((&BlockHead{Version: Version0, Number: 9}).StateRootRequired(), ShouldBeFalse)
+			So((&BlockHead{Version: Version2, Number: 10}).StateRootRequired(), ShouldBeTrue)
+			StateRootHeight = 20
+			So(CheckStateRootHeight(), ShouldBeNil)
+			So((&BlockHead{Version: Version2, Number: 19}).StateRootRequired(), ShouldBeFalse)
+			So((&BlockHead{Version: Version2, Number: 20}).StateRootRequired(), ShouldBeTrue)
+			StateRootHeight = 5
+			So(CheckStateRootHeight(), ShouldEqual, ErrStateRootHeight)
+			Version2Height = -1
+			StateRootHeight = 20
+			So(CheckStateRootHeight(), ShouldEqual, ErrStateRootHeight)
+		})
+	})
+}
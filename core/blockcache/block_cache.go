@@ -84,6 +84,20 @@ func (b *BlockCacheTree) updateLength() {
 	}
 }
 
+// flush commits the block of this node and its state patch, the block and patch are
+// written in one batch first and block.ReplayState recovers the state database from
+// it when the node stops before the state is flushed
+func (b *BlockCacheTree) flush() error {
+	blk := b.bc.Top()
+	b.pool.Put(state.Key("BlockNum"), state.MakeVInt(int(blk.Head.Number)))
+	b.pool.Put(state.Key("BlockHash"), state.MakeVByte(blk.HeadHash()))
+	err := b.bc.FlushWithPatch(b.pool.GetPatch())
+	if err != nil {
+		return err
+	}
+	return b.pool.Flush()
+}
+
 func (b *BlockCacheTree) iterate(fun func(bct *BlockCacheTree) bool) bool {
 	if fun(b) {
 		return true
@@ -119,6 +133,7 @@ type BlockCache interface {
 	BlockConfirmChan() chan uint64
 	OnBlockChan() chan *block.Block
 	SendOnBlock(blk *block.Block)
+	OnReorgChan() chan []*block.Block
 }
 
 type BlockCacheImpl struct {
@@ -129,6 +144,7 @@ type BlockCacheImpl struct {
 	maxDepth           int
 	blkConfirmChan     chan uint64
 	chConfirmBlockData chan *block.Block
+	chReorg            chan []*block.Block
 }
 
 func NewBlockCache(chain block.Chain, pool state.Pool, maxDepth int) *BlockCacheImpl {
@@ -151,6 +167,7 @@ func NewBlockCache(chain block.Chain, pool state.Pool, maxDepth int) *BlockCache
 		maxDepth:           maxDepth,
 		blkConfirmChan:     make(chan uint64, 10),
 		chConfirmBlockData: make(chan *block.Block, 100),
+		chReorg:            make(chan []*block.Block, 100),
 	}
 	if h.cachedRoot.bc.Top() != nil {
 		h.hashMap.Store(string(h.cachedRoot.bc.Top().HeadHash()), h.cachedRoot)
@@ -314,18 +331,22 @@ func (h *BlockCacheImpl) delSubTree(root *BlockCacheTree) {
 }
 
 func (h *BlockCacheImpl) tryFlush(version int64) {
+	abandoned := make([]*block.Block, 0)
 	for {
 		need, newRoot := h.needFlush(version)
 		if need {
 			for _, bct := range h.cachedRoot.children {
 				if bct != newRoot {
+					bct.iterate(func(b *BlockCacheTree) bool {
+						abandoned = append(abandoned, b.bc.Top())
+						return false
+					})
 					h.delSubTree(bct)
 				}
 			}
 			h.hashMap.Delete(string(h.cachedRoot.bc.Top().HeadHash()))
 			h.cachedRoot = newRoot
-			h.cachedRoot.bc.Flush()
-			err := h.cachedRoot.pool.Flush()
+			err := h.cachedRoot.flush()
 			if err != nil {
 				log.Log.E("Database error，failed to tryFlush err:%v", err)
 			}
@@ -336,18 +357,21 @@ func (h *BlockCacheImpl) tryFlush(version int64) {
 			break
 		}
 	}
+	if len(abandoned) > 0 {
+		h.sendReorg(abandoned)
+	}
 }
 
 func (h *BlockCacheImpl) needFlush(version int64) (bool, *BlockCacheTree) {
-	switch version {
-	case 0:
+	switch block.VersionFinality(version) {
+	case block.ConfirmFinality:
 		for _, bct := range h.cachedRoot.children {
 			if bct.bc.confirmed > h.maxDepth {
 				return true, bct
 			}
 		}
 		return false, nil
-	case 1:
+	case block.DepthFinality:
 		if h.cachedRoot.bc.depth > h.maxDepth {
 			return true, h.cachedRoot.popLongest()
 		}
@@ -424,3 +448,17 @@ func (h *BlockCacheImpl) OnBlockChan() chan *block.Block {
 func (h *BlockCacheImpl) SendOnBlock(blk *block.Block) {
 	h.chConfirmBlockData <- blk
 }
+
+// OnReorgChan carries the blocks of the branches dropped when a block became final
+func (h *BlockCacheImpl) OnReorgChan() chan []*block.Block {
+	return h.chReorg
+}
+
+// sendReorg hands the dropped blocks to OnReorgChan, without blocking when nobody reads it
+func (h *BlockCacheImpl) sendReorg(blks []*block.Block) {
+	select {
+	case h.chReorg <- blks:
+	default:
+		log.Log.E("reorg channel full, %v dropped blocks not reported", len(blks))
+	}
+}
@@ -22,6 +22,8 @@ func TestBlockCachePoW(t *testing.T) {
 	pool := core_mock.NewMockPool(ctl)
 
 	pool.EXPECT().Flush().AnyTimes().Return(nil)
+	pool.EXPECT().Put(gomock.Any(), gomock.Any()).AnyTimes()
+	pool.EXPECT().GetPatch().AnyTimes().Return(state.Patch{})
 
 	main := lua.NewMethod(vm.Public, "main", 0, 1)
 	code := `function main()
@@ -112,7 +114,7 @@ func TestBlockCachePoW(t *testing.T) {
 
 			Convey("auto push", func() {
 				var ans int64
-				base.EXPECT().Push(gomock.Any()).AnyTimes().Do(func(block *block.Block) error {
+				base.EXPECT().PushWithPatch(gomock.Any(), gomock.Any()).AnyTimes().Do(func(block *block.Block, patch state.Patch) error {
 					ans = block.Content[0].Nonce
 					return nil
 				})
@@ -127,6 +129,34 @@ func TestBlockCachePoW(t *testing.T) {
 				bc.Add(&b4, verifier)
 				So(ans, ShouldEqual, 1)
 			})
+
+			Convey("reorg", func() {
+				base.EXPECT().PushWithPatch(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				verifier = func(blk *block.Block, parent *block.Block, pool state.Pool) (state.Pool, error) {
+					return pool, nil
+				}
+				fork := block.Block{
+					Head: block.BlockHead{
+						Version:    1,
+						ParentHash: b1.HeadHash(),
+						Witness:    "fork",
+					},
+					Content: []tx.Tx{tx.NewTx(-2, &lc)},
+				}
+				bc := NewBlockCache(base, pool, 2)
+				bc.Add(&b1, verifier)
+				bc.Add(&b2, verifier)
+				bc.Add(&fork, verifier)
+				bc.Add(&b3, verifier)
+				So(len(bc.OnReorgChan()), ShouldEqual, 0)
+				bc.Add(&b4, verifier)
+				So(len(bc.OnReorgChan()), ShouldEqual, 1)
+				dropped := <-bc.OnReorgChan()
+				So(len(dropped), ShouldEqual, 1)
+				So(dropped[0].HeadHash(), ShouldResemble, fork.HeadHash())
+				_, err := bc.FindBlockInCache(fork.HeadHash())
+				So(err, ShouldNotBeNil)
+			})
 		})
 
 		Convey("Longest chain", func() {
@@ -175,6 +205,8 @@ func TestBlockCachePoB(t *testing.T) {
 	pool := core_mock.NewMockPool(ctl)
 
 	pool.EXPECT().Flush().AnyTimes().Return(nil)
+	pool.EXPECT().Put(gomock.Any(), gomock.Any()).AnyTimes()
+	pool.EXPECT().GetPatch().AnyTimes().Return(state.Patch{})
 
 	main := lua.NewMethod(vm.Public, "main", 0, 1)
 	code := `function main()
@@ -248,7 +280,7 @@ func TestBlockCachePoB(t *testing.T) {
 	Convey("Test of Block Cache (PoB)", t, func() {
 		Convey("Add:", func() {
 			var ans int64
-			base.EXPECT().Push(gomock.Any()).Do(func(block *block.Block) error {
+			base.EXPECT().PushWithPatch(gomock.Any(), gomock.Any()).Do(func(block *block.Block, patch state.Patch) error {
 				ans = block.Content[0].Nonce
 				return nil
 			})
@@ -395,11 +427,7 @@ func TestStatePool(t *testing.T) {
 
 		base := core_mock.NewMockChain(ctl)
 		base.EXPECT().Top().AnyTimes().Return(&b0)
-		var ans int64
-		base.EXPECT().Push(gomock.Any()).Do(func(block *block.Block) error {
-			ans = block.Content[0].Nonce
-			return nil
-		})
+		base.EXPECT().Push(gomock.Any()).AnyTimes().Return(nil)
 
 		bc := NewBlockCache(base, pool, 10)
 
@@ -420,3 +448,24 @@ func TestStatePool(t *testing.T) {
 
 	})
 }
+
+func TestCBCIterator(t *testing.T) {
+	Convey("Test of the cached chain iterator", t, func() {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		// the persisted chain expects no call, the iterator stops at the cache
+		base := core_mock.NewMockChain(ctl)
+
+		b1 := &block.Block{Head: block.BlockHead{Number: 1}}
+		b2 := &block.Block{Head: block.BlockHead{Number: 2}}
+		root := NewCBC(base)
+		c1 := CachedBlockChain{Chain: base, block: b1, parent: &root}
+		c2 := CachedBlockChain{Chain: base, block: b2, parent: &c1}
+
+		iter := c2.Iterator()
+		So(iter.Next(), ShouldEqual, b2)
+		So(iter.Next(), ShouldEqual, b1)
+		So(iter.Next(), ShouldBeNil)
+		So(iter.Next(), ShouldBeNil)
+	})
+}
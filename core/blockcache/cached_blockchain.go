@@ -2,6 +2,7 @@ package blockcache
 
 import (
 	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
 
 	"bytes"
 
@@ -28,14 +29,14 @@ func NewCBC(chain block.Chain) CachedBlockChain {
 	}
 }
 
-func (c *CachedBlockChain) Push(block *block.Block) error {
-	c.block = block
+func (c *CachedBlockChain) Push(blk *block.Block) error {
+	c.block = blk
 	c.cachedLength++
 
-	switch block.Head.Version {
-	case 0:
+	switch block.VersionFinality(blk.Head.Version) {
+	case block.ConfirmFinality:
 		c.confirmed = 1
-		witness := block.Head.Witness
+		witness := blk.Head.Witness
 		confirmed := make(map[string]int)
 		confirmed[witness] = 1
 		cbc := c
@@ -51,7 +52,7 @@ func (c *CachedBlockChain) Push(block *block.Block) error {
 			cbc = cbc.parent
 		}
 		fallthrough
-	case 1:
+	case block.DepthFinality:
 		c.depth = 0
 		cbc := c
 		depth := 0
@@ -100,25 +101,42 @@ func (c *CachedBlockChain) Flush() {
 	}
 }
 
+// FlushWithPatch persists the cached block together with its state patch
+func (c *CachedBlockChain) FlushWithPatch(patch state.Patch) error {
+	if c.block == nil {
+		return nil
+	}
+	err := c.Chain.PushWithPatch(c.block, patch)
+	if err != nil {
+		return err
+	}
+	c.block = nil
+	c.cachedLength = 0
+	c.parent = nil
+	return nil
+}
+
+// Iterator walks the blocks still in the cache, the persisted chain below them
+// is walked with the iterators of the embedded Chain
 func (c *CachedBlockChain) Iterator() block.ChainIterator {
-	return &CBCIterator{c, nil}
+	return &CBCIterator{c}
 }
 
 type CBCIterator struct {
-	pc       *CachedBlockChain
-	iterator block.ChainIterator
+	pc *CachedBlockChain
 }
 
+// Next returns the cached blocks from the top down and nil past the last one,
+// nodes whose block is already flushed are skipped
 func (ci *CBCIterator) Next() *block.Block {
-	if ci.iterator != nil {
-		return ci.iterator.Next()
-	}
-	p := ci.pc.block
-	if ci.pc.parent == nil {
-		ci.iterator = ci.pc.Chain.Iterator()
+	for ci.pc != nil {
+		p := ci.pc.block
+		ci.pc = ci.pc.parent
+		if p != nil {
+			return p
+		}
 	}
-	ci.pc = ci.pc.parent
-	return p
+	return nil
 }
 
 func (c *CachedBlockChain) GetBlockByNumber(number uint64) *block.Block {
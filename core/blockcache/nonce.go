@@ -0,0 +1,62 @@
+package blockcache
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
+)
+
+// nonceKey is the state hash holding the last nonce used by each publisher
+const nonceKey = state.Key("nonce")
+
+var (
+	ErrNonceUsed = errors.New("nonce already used")
+	ErrNonceGap  = errors.New("nonce skips unused nonces")
+)
+
+// LastNonce returns the last nonce used by account in pool, -1 if it never published a tx
+func LastNonce(account vm.IOSTAccount, pool state.Pool) (int64, error) {
+	val0, err := pool.GetHM(nonceKey, state.Key(account))
+	if err != nil || val0 == state.VNil {
+		return -1, nil
+	}
+	val, ok := val0.(*state.VInt)
+	if !ok {
+		return -1, fmt.Errorf("pool type error: should VInt, acture %v; in nonce.%v",
+			reflect.TypeOf(val0).String(), string(account))
+	}
+	return int64(val.ToInt()), nil
+}
+
+// CheckNonce tells whether txx is the next tx of its publisher in pool, the nonces
+// of a publisher start at 0 and grow by one with each tx. Legacy txs carry no
+// replay protection and always pass.
+func CheckNonce(txx *tx.Tx, pool state.Pool) error {
+	if txx.Legacy() {
+		return nil
+	}
+	last, err := LastNonce(vm.PubkeyToIOSTAccount(txx.Publisher.Pubkey), pool)
+	if err != nil {
+		return err
+	}
+	switch {
+	case txx.Nonce <= last:
+		return ErrNonceUsed
+	case txx.Nonce > last+1:
+		return ErrNonceGap
+	}
+	return nil
+}
+
+// useNonce records the nonce of txx as the last one used by its publisher
+func useNonce(txx *tx.Tx, pool state.Pool) error {
+	if err := CheckNonce(txx, pool); err != nil || txx.Legacy() {
+		return err
+	}
+	publisher := vm.PubkeyToIOSTAccount(txx.Publisher.Pubkey)
+	return pool.PutHM(nonceKey, state.Key(publisher), state.MakeVInt(int(txx.Nonce)))
+}
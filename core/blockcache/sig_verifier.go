@@ -0,0 +1,126 @@
+package blockcache
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+)
+
+// SigVerifier checks tx signatures on a pool of workers and remembers the hashes of
+// the txs that passed, so a tx checked when it enters the tx pool is not checked
+// again when its block is verified
+type SigVerifier struct {
+	workers int
+	cache   sigCache
+}
+
+// StdSigVerifier is shared by the tx pool intake and the block verification
+var StdSigVerifier = NewSigVerifier(runtime.NumCPU(), 1<<16)
+
+// NewSigVerifier returns a verifier running up to workers checks at once and
+// remembering about cacheSize verified txs
+func NewSigVerifier(workers, cacheSize int) *SigVerifier {
+	if workers < 1 {
+		workers = 1
+	}
+	return &SigVerifier{
+		workers: workers,
+		cache:   newSigCache(cacheSize),
+	}
+}
+
+// Verify checks the signatures of t unless they were verified before
+func (v *SigVerifier) Verify(t *tx.Tx) error {
+	hash := string(t.Hash())
+	if v.cache.has(hash) {
+		return nil
+	}
+	if err := t.VerifySelf(); err != nil {
+		return err
+	}
+	v.cache.add(hash)
+	return nil
+}
+
+// VerifyBatch checks the signatures of txs in parallel, the error of txs[i] is at
+// index i of the result
+func (v *SigVerifier) VerifyBatch(txs []*tx.Tx) []error {
+	errs := make([]error, len(txs))
+	workers := v.workers
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	var next int64 = -1
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(txs) {
+					return
+				}
+				errs[i] = v.Verify(txs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// VerifyTxs checks the signatures of txs in parallel and returns the first error found
+func (v *SigVerifier) VerifyTxs(txs []tx.Tx) error {
+	ptxs := make([]*tx.Tx, len(txs))
+	for i := range txs {
+		ptxs[i] = &txs[i]
+	}
+	for _, err := range v.VerifyBatch(ptxs) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sigCache keeps two generations of hashes, when the current one is full it becomes
+// the old one and the former old one is dropped
+type sigCache struct {
+	mu      sync.RWMutex
+	size    int
+	current map[string]struct{}
+	old     map[string]struct{}
+}
+
+func newSigCache(size int) sigCache {
+	if size < 2 {
+		size = 2
+	}
+	return sigCache{
+		size:    size / 2,
+		current: make(map[string]struct{}),
+		old:     make(map[string]struct{}),
+	}
+}
+
+func (c *sigCache) has(hash string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if _, ok := c.current[hash]; ok {
+		return true
+	}
+	_, ok := c.old[hash]
+	return ok
+}
+
+func (c *sigCache) add(hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.current) >= c.size {
+		c.old = c.current
+		c.current = make(map[string]struct{})
+	}
+	c.current[hash] = struct{}{}
+}
@@ -0,0 +1,107 @@
+package blockcache
+
+import (
+	"testing"
+
+	"github.com/iost-official/Go-IOS-Protocol/account"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
+	"github.com/iost-official/Go-IOS-Protocol/vm/lua"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func genSignedTxs(n int) []*tx.Tx {
+	main := lua.NewMethod(2, "main", 0, 1)
+	code := `function main()
+				return "success"
+			end`
+	signer, _ := account.NewAccount(nil)
+	publisher, _ := account.NewAccount(nil)
+	txs := make([]*tx.Tx, 0, n)
+	for i := 0; i < n; i++ {
+		lc := lua.NewContract(vm.ContractInfo{Prefix: "sig", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount(publisher.ID)}, code, main)
+		txx := tx.NewTx(int64(i), &lc)
+		sig, _ := tx.SignContract(txx, signer)
+		txx, _ = tx.SignTx(txx, publisher, sig)
+		txs = append(txs, &txx)
+	}
+	return txs
+}
+
+func TestSigVerifier(t *testing.T) {
+	Convey("Test of SigVerifier", t, func() {
+		v := NewSigVerifier(4, 100)
+		txs := genSignedTxs(20)
+
+		Convey("verify a batch", func() {
+			broken := *txs[7]
+			broken.Nonce++
+			txs[7] = &broken
+
+			errs := v.VerifyBatch(txs)
+			So(len(errs), ShouldEqual, len(txs))
+			for i, err := range errs {
+				if i == 7 {
+					So(err, ShouldNotBeNil)
+				} else {
+					So(err, ShouldBeNil)
+				}
+			}
+			So(v.cache.has(string(txs[0].Hash())), ShouldBeTrue)
+			So(v.cache.has(string(txs[7].Hash())), ShouldBeFalse)
+		})
+
+		Convey("verify block content", func() {
+			content := make([]tx.Tx, 0, len(txs))
+			for _, txx := range txs {
+				content = append(content, *txx)
+			}
+			So(v.VerifyTxs(content), ShouldBeNil)
+			content[3].Publisher.Sig = []byte("broken")
+			So(v.VerifyTxs(content), ShouldNotBeNil)
+		})
+
+		Convey("cache keeps two generations", func() {
+			c := newSigCache(4)
+			for _, h := range []string{"a", "b", "c", "d"} {
+				c.add(h)
+			}
+			So(c.has("a"), ShouldBeTrue)
+			So(c.has("d"), ShouldBeTrue)
+			c.add("e")
+			So(c.has("a"), ShouldBeFalse)
+			So(c.has("c"), ShouldBeTrue)
+			So(c.has("e"), ShouldBeTrue)
+		})
+	})
+}
+
+func benchmarkVerify(b *testing.B, verify func(txs []*tx.Tx)) {
+	txs := genSignedTxs(800)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		verify(txs)
+	}
+	b.ReportMetric(float64(len(txs)*b.N)/b.Elapsed().Seconds(), "txs/s")
+}
+
+func BenchmarkVerifySerial(b *testing.B) {
+	benchmarkVerify(b, func(txs []*tx.Tx) {
+		for _, txx := range txs {
+			txx.VerifySelf()
+		}
+	})
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	benchmarkVerify(b, func(txs []*tx.Tx) {
+		NewSigVerifier(StdSigVerifier.workers, len(txs)).VerifyBatch(txs)
+	})
+}
+
+func BenchmarkVerifyBatchCached(b *testing.B) {
+	v := NewSigVerifier(StdSigVerifier.workers, 1<<16)
+	benchmarkVerify(b, func(txs []*tx.Tx) {
+		v.VerifyBatch(txs)
+	})
+}
@@ -29,10 +29,17 @@ func VerifyBlockHead(blk *block.Block, parentBlk *block.Block) error {
 	if bh.Number != parentBlk.Head.Number+1 {
 		return errors.New("wrong number")
 	}
+	// heads switch to version 2 at block.Version2Height
+	if bh.Extended() != (block.VersionAt(bh.Number) == block.Version2) {
+		return errors.New("wrong version")
+	}
 	treeHash := blk.CalculateTreeHash()
 	if !bytes.Equal(treeHash, bh.TreeHash) {
 		return errors.New("wrong tree hash")
 	}
+	if bh.Extended() && bh.TxCount != int64(len(blk.Content)) {
+		return errors.New("wrong tx count")
+	}
 	return nil
 }
 
@@ -41,25 +48,68 @@ var verb *verifier.CacheVerifier
 
 var blockLock sync.Mutex
 
-func StdBlockVerifier(block *block.Block, pool state.Pool) (state.Pool, error) {
+// StdBlockVerifier runs the txs of blk on pool and checks the receipts root, the gas
+// used and the state root of the head against the outcome
+func StdBlockVerifier(blk *block.Block, pool state.Pool) (state.Pool, error) {
+	return executeBlock(blk, pool, false)
+}
+
+// StdBlockProducer runs the txs of blk on pool like StdBlockVerifier, for a block being
+// generated: the receipts root and the gas used of a version 2 head, and its state root
+// from block.StateRootHeight on, are filled from the outcome instead of checked
+func StdBlockProducer(blk *block.Block, pool state.Pool) (state.Pool, error) {
+	return executeBlock(blk, pool, true)
+}
+
+func executeBlock(blk *block.Block, pool state.Pool, produce bool) (state.Pool, error) {
 	blockLock.Lock()
 	defer blockLock.Unlock()
 	ver.Context = vm.NewContext(vm.BaseContext())
-	ver.Context.ParentHash = block.Head.ParentHash
-	ver.Context.Timestamp = block.Head.Time
-	ver.Context.BlockHeight = block.Head.Number
-	ver.Context.Witness = vm.IOSTAccount(block.Head.Witness)
+	ver.Context.ParentHash = blk.Head.ParentHash
+	ver.Context.Timestamp = blk.Head.Time
+	ver.Context.BlockHeight = blk.Head.Number
+	ver.Context.Witness = vm.IOSTAccount(blk.Head.Witness)
 
-	txs := block.Content
+	txs := blk.Content
 	ptxs := make([]*tx.Tx, 0)
 	for i := range txs {
 		ptxs = append(ptxs, &(txs[i]))
 	}
-	pool2, _, err := StdTxsVerifier(ptxs, pool.Copy())
+	pool2, receipts, err := StdTxsExecutor(ptxs, pool.Copy())
+	if err != nil {
+		return pool, err
+	}
+	extended := blk.Head.Extended()
+	if produce && extended {
+		blk.Head.ReceiptsRoot = block.ReceiptsRoot(receipts)
+		blk.Head.GasUsed = int64(block.ReceiptsGasUsed(receipts))
+	}
+	if (extended || len(blk.Head.ReceiptsRoot) > 0) && !bytes.Equal(block.ReceiptsRoot(receipts), blk.Head.ReceiptsRoot) {
+		return pool, errors.New("wrong receipts root")
+	}
+	if extended && uint64(blk.Head.GasUsed) != block.ReceiptsGasUsed(receipts) {
+		return pool, errors.New("wrong gas used")
+	}
+	pool3, err := pool2.MergeParent()
 	if err != nil {
 		return pool, err
 	}
-	return pool2.MergeParent()
+	// version 2 heads below block.StateRootHeight may leave the state root empty
+	required := blk.Head.StateRootRequired()
+	if required || len(blk.Head.StateRoot) > 0 {
+		root, err := pool3.Root()
+		if err != nil {
+			return pool, err
+		}
+		if produce && required {
+			blk.Head.StateRoot = root
+		}
+		if !bytes.Equal(root, blk.Head.StateRoot) {
+			return pool, errors.New("wrong state root")
+		}
+	}
+	blk.Receipts = receipts
+	return pool3, nil
 }
 
 func StdTxsVerifier(txs []*tx.Tx, pool state.Pool) (state.Pool, int, error) {
@@ -77,6 +127,38 @@ func StdTxsVerifier(txs []*tx.Tx, pool state.Pool) (state.Pool, int, error) {
 	return pool2, len(txs), nil
 }
 
+// StdTxsExecutor runs txs in order and returns a receipt for each of them, a tx that
+// fails during execution gets a failed receipt, any other error, a used nonce
+// among them, rejects the whole list
+func StdTxsExecutor(txs []*tx.Tx, pool state.Pool) (state.Pool, []*block.Receipt, error) {
+	pool2 := pool.Copy()
+	receipts := make([]*block.Receipt, 0, len(txs))
+	for _, txx := range txs {
+		if err := useNonce(txx, pool2); err != nil {
+			return pool2, nil, err
+		}
+		var res *verifier.Result
+		var err error
+		pool2, res, err = ver.ExecuteContract(txx.Contract, pool2)
+		if err != nil {
+			return pool2, nil, err
+		}
+		receipt := &block.Receipt{
+			TxHash:  txx.Hash(),
+			Status:  block.ReceiptSuccess,
+			GasUsed: res.Gas,
+			Fee:     res.Fee,
+			Logs:    res.Logs,
+		}
+		if res.Err != nil {
+			receipt.Status = block.ReceiptFailed
+			receipt.Error = res.Err.Error()
+		}
+		receipts = append(receipts, receipt)
+	}
+	return pool2, receipts, nil
+}
+
 func CleanStdVerifier() {
 	verb.CleanUp()
 }
@@ -98,14 +180,19 @@ func StdCacheVerifier(txx *tx.Tx, pool state.Pool, context *vm.Context) error {
 				err = err0.(error)
 			}
 		}()
-		p2, err = verb.VerifyContract(txx.Contract, pool.Copy())
+		p2 = pool.Copy()
+		if err = useNonce(txx, p2); err != nil {
+			return
+		}
+		p2, err = verb.VerifyContract(txx.Contract, p2)
 	}) {
 		if err != nil {
 			host.Log(err.Error(), txx.Contract.Info().Prefix)
 			return err
 		}
-		p2.MergeParent()
-		return nil
+		// the changes of txx go to pool, the next txs of a block are checked on top of them
+		patch := p2.GetPatch()
+		return patch.Apply(pool)
 	} else {
 		return errors.New("time out")
 	}
@@ -120,7 +207,7 @@ func (v VerifyContext) ParentHash() []byte {
 }
 
 func VerifyTxSig(tx tx.Tx) bool {
-	err := tx.VerifySelf()
+	err := StdSigVerifier.Verify(&tx)
 	return err == nil
 }
 
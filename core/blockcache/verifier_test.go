@@ -33,7 +33,7 @@ func TestStdTxsVerifier(t *testing.T) {
 
 		txs := make([]*tx.Tx, 0)
 
-		pool.PutHM("iost", "a", state.MakeVFloat(100000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(100000))
 
 		for j := 0; j < 100; j++ {
 			lc := lua.NewContract(vm.ContractInfo{Prefix: strconv.Itoa(j), GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
@@ -68,7 +68,7 @@ func TestStdCacheVerifier(t *testing.T) {
 				return "success"
 			end`
 
-		pool.PutHM("iost", "a", state.MakeVFloat(100000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(100000))
 
 		for j := 0; j < 90; j++ {
 			lc := lua.NewContract(vm.ContractInfo{Prefix: strconv.Itoa(j), GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
@@ -77,7 +77,7 @@ func TestStdCacheVerifier(t *testing.T) {
 		}
 		v, err := pool.GetHM("iost", "a")
 		So(err, ShouldBeNil)
-		So(v.EncodeString(), ShouldEqual, "f9.459100000000139e+03")
+		So(v.EncodeString(), ShouldEqual, "d9459.1")
 		p := pool.(*state.PoolImpl)
 		count := 0
 		for p != nil {
@@ -105,10 +105,10 @@ func TestStdCacheVerifier(t *testing.T) {
 	return "success"
 end`
 
-		pool.PutHM("iost", "a", state.MakeVFloat(100000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(100000))
 
 		lc := lua.NewContract(vm.ContractInfo{Prefix: "ahaha", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
-		txx := tx.NewTx(1, &lc)
+		txx := tx.NewTx(0, &lc)
 
 		ctx := vm.NewContext(vm.BaseContext())
 		ctx.ParentHash = []byte{1}
@@ -122,16 +122,68 @@ end`
 		balance, err := pool.GetHM("iost", "a")
 		So(err, ShouldBeNil)
 
-		So(balance.(*state.VFloat).ToFloat64() > 90000, ShouldBeTrue)
+		So(balance.(*state.VDecimal).Float64() > 90000, ShouldBeTrue)
 
 		ctx2 := vm.NewContext(ctx)
 		ctx2.ParentHash = []byte{}
 
-		err = StdCacheVerifier(&txx, pool, ctx2)
+		txx2 := tx.NewTx(1, &lc)
+		err = StdCacheVerifier(&txx2, pool, ctx2)
 		So(err, ShouldBeNil)
 		balance, err = pool.GetHM("iost", "a")
 		So(err, ShouldBeNil)
-		So(balance.(*state.VFloat).ToFloat64() < 90000, ShouldBeTrue)
+		So(balance.(*state.VDecimal).Float64() < 90000, ShouldBeTrue)
+	})
+
+	Convey("Test of nonce", t, func() {
+		dbx, err := db.NewMemDatabase()
+		So(err, ShouldBeNil)
+		sdb := state.NewDatabase(dbx)
+		pool := state.NewPool(sdb)
+		main := lua.NewMethod(2, "main", 0, 1)
+		code := `function main()
+				return "success"
+			end`
+
+		pool.PutHM("iost", "a", state.DecimalFromInt(100000))
+
+		acc, err := account.NewAccount(nil)
+		So(err, ShouldBeNil)
+		lc := lua.NewContract(vm.ContractInfo{Prefix: "nonce", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
+		txx, err := tx.SignTx(tx.NewTx(0, &lc), acc)
+		So(err, ShouldBeNil)
+		publisher := vm.PubkeyToIOSTAccount(acc.Pubkey)
+		lastNonce := func(pool state.Pool) int64 {
+			last, err := LastNonce(publisher, pool)
+			So(err, ShouldBeNil)
+			return last
+		}
+		So(lastNonce(pool), ShouldEqual, -1)
+
+		So(StdCacheVerifier(&txx, pool, vm.NewContext(vm.BaseContext())), ShouldBeNil)
+		So(lastNonce(pool), ShouldEqual, 0)
+		So(StdCacheVerifier(&txx, pool, vm.NewContext(vm.BaseContext())), ShouldEqual, ErrNonceUsed)
+
+		gap, err := tx.SignTx(tx.NewTx(2, &lc), acc)
+		So(err, ShouldBeNil)
+		So(CheckNonce(&gap, pool), ShouldEqual, ErrNonceGap)
+		_, _, err = StdTxsExecutor([]*tx.Tx{&gap}, pool)
+		So(err, ShouldEqual, ErrNonceGap)
+
+		next, err := tx.SignTx(tx.NewTx(1, &lc), acc)
+		So(err, ShouldBeNil)
+		So(CheckNonce(&next, pool), ShouldBeNil)
+		_, _, err = StdTxsExecutor([]*tx.Tx{&next, &next}, pool)
+		So(err, ShouldEqual, ErrNonceUsed)
+
+		p2, _, err := StdTxsExecutor([]*tx.Tx{&next, &gap}, pool)
+		So(err, ShouldBeNil)
+		So(lastNonce(p2), ShouldEqual, 2)
+
+		pool.PutHM("nonce", state.Key(publisher), state.MakeVString("broken"))
+		_, err = LastNonce(publisher, pool)
+		So(err, ShouldNotBeNil)
+		So(CheckNonce(&next, pool), ShouldNotBeNil)
 	})
 }
 
@@ -176,7 +228,7 @@ func BenchmarkStdCacheVerifier(b *testing.B) {
 				return "success"
 			end`
 
-	pool.PutHM("iost", "a", state.MakeVFloat(1000000000))
+	pool.PutHM("iost", "a", state.DecimalFromInt(1000000000))
 
 	lc := lua.NewContract(vm.ContractInfo{Prefix: "ahaha", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
 	txx := tx.NewTx(123, &lc)
@@ -332,7 +384,7 @@ end--f
 		So(err, ShouldBeNil)
 		sdb := state.NewDatabase(dbx)
 		pool := state.NewPool(sdb)
-		pool.PutHM("iost", "iWgLQj3VTPN4dZnomuJMMCggv22LFw4nAkA6bmrVsmCo", state.MakeVFloat(10000))
+		pool.PutHM("iost", "iWgLQj3VTPN4dZnomuJMMCggv22LFw4nAkA6bmrVsmCo", state.DecimalFromInt(10000))
 
 		acc, err := account.NewAccount(common.Base58Decode("3BZ3HWs2nWucCCvLp7FRFv1K7RR3fAjjEQccf9EJrTv4"))
 		if err != nil {
@@ -348,7 +400,7 @@ end--f
 		parser, _ := lua.NewDocCommentParser(rawCode)
 		contract, err = parser.Parse()
 		So(err, ShouldBeNil)
-		mtx := tx.NewTx(1, contract)
+		mtx := tx.NewTx(0, contract)
 		stx, err := tx.SignTx(mtx, acc)
 		So(err, ShouldBeNil)
 		buf := stx.Encode()
@@ -365,7 +417,7 @@ func TestStdCacheVerifierTimeout(t *testing.T) {
 	Convey("test of timeout", t, func() {
 		fib := `
 --- main 
--- @gas_limit 10000000
+-- @gas_limit 10000000000
 -- @gas_price 0.00001
 -- @param_cnt 0
 -- @return_cnt 1
@@ -396,7 +448,7 @@ end--f
 		So(err, ShouldBeNil)
 		sdb := state.NewDatabase(dbx)
 		pool := state.NewPool(sdb)
-		pool.PutHM("iost", "a", state.MakeVFloat(1000000000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(1000000000))
 
 		parser, err := lua.NewDocCommentParser(fib)
 		So(err, ShouldBeNil)
@@ -404,7 +456,7 @@ end--f
 		So(err, ShouldBeNil)
 		contract.SetSender("a")
 		So(err, ShouldBeNil)
-		mtx := tx.NewTx(1, contract)
+		mtx := tx.NewTx(0, contract)
 		err = StdCacheVerifier(&mtx, pool, vm.BaseContext())
 		So(err, ShouldNotBeNil)
 		So(err.Error(), ShouldEqual, "time out")
@@ -415,7 +467,7 @@ end--f
 		So(err, ShouldBeNil)
 		contract.SetSender("a")
 		So(err, ShouldBeNil)
-		mtx = tx.NewTx(1, contract)
+		mtx = tx.NewTx(0, contract)
 		err = StdCacheVerifier(&mtx, pool, vm.BaseContext())
 		So(err, ShouldBeNil)
 
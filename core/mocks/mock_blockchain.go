@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iost-official/Go-IOS-Protocol/core/block"
+	state "github.com/iost-official/Go-IOS-Protocol/core/state"
 	tx "github.com/iost-official/Go-IOS-Protocol/core/tx"
 )
 
@@ -47,6 +48,18 @@ func (mr *MockChainMockRecorder) CheckLength() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLength", reflect.TypeOf((*MockChain)(nil).CheckLength))
 }
 
+// ForwardIterator mocks base method
+func (m *MockChain) ForwardIterator(arg0 uint64) block.ChainIterator {
+	ret := m.ctrl.Call(m, "ForwardIterator", arg0)
+	ret0, _ := ret[0].(block.ChainIterator)
+	return ret0
+}
+
+// ForwardIterator indicates an expected call of ForwardIterator
+func (mr *MockChainMockRecorder) ForwardIterator(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardIterator", reflect.TypeOf((*MockChain)(nil).ForwardIterator), arg0)
+}
+
 // GetBlockByHash mocks base method
 func (m *MockChain) GetBlockByHash(arg0 []byte) *block.Block {
 	ret := m.ctrl.Call(m, "GetBlockByHash", arg0)
@@ -84,6 +97,32 @@ func (mr *MockChainMockRecorder) GetBlockByteByHash(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByteByHash", reflect.TypeOf((*MockChain)(nil).GetBlockByteByHash), arg0)
 }
 
+// GetBlockNumberByTime mocks base method
+func (m *MockChain) GetBlockNumberByTime(arg0 int64) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetBlockNumberByTime", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockNumberByTime indicates an expected call of GetBlockNumberByTime
+func (mr *MockChainMockRecorder) GetBlockNumberByTime(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumberByTime", reflect.TypeOf((*MockChain)(nil).GetBlockNumberByTime), arg0)
+}
+
+// GetBlockNumbersByWitness mocks base method
+func (m *MockChain) GetBlockNumbersByWitness(arg0 string, arg1 uint64, arg2 int) ([]uint64, error) {
+	ret := m.ctrl.Call(m, "GetBlockNumbersByWitness", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockNumbersByWitness indicates an expected call of GetBlockNumbersByWitness
+func (mr *MockChainMockRecorder) GetBlockNumbersByWitness(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumbersByWitness", reflect.TypeOf((*MockChain)(nil).GetBlockNumbersByWitness), arg0, arg1, arg2)
+}
+
 // GetHashByNumber mocks base method
 func (m *MockChain) GetHashByNumber(arg0 uint64) []byte {
 	ret := m.ctrl.Call(m, "GetHashByNumber", arg0)
@@ -96,6 +135,45 @@ func (mr *MockChainMockRecorder) GetHashByNumber(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashByNumber", reflect.TypeOf((*MockChain)(nil).GetHashByNumber), arg0)
 }
 
+// GetReceipt mocks base method
+func (m *MockChain) GetReceipt(arg0 []byte) (*block.Receipt, error) {
+	ret := m.ctrl.Call(m, "GetReceipt", arg0)
+	ret0, _ := ret[0].(*block.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt
+func (mr *MockChainMockRecorder) GetReceipt(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockChain)(nil).GetReceipt), arg0)
+}
+
+// GetReceipts mocks base method
+func (m *MockChain) GetReceipts(arg0 []byte) ([]*block.Receipt, error) {
+	ret := m.ctrl.Call(m, "GetReceipts", arg0)
+	ret0, _ := ret[0].([]*block.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipts indicates an expected call of GetReceipts
+func (mr *MockChainMockRecorder) GetReceipts(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipts", reflect.TypeOf((*MockChain)(nil).GetReceipts), arg0)
+}
+
+// GetStatePatch mocks base method
+func (m *MockChain) GetStatePatch(arg0 []byte) (*state.Patch, error) {
+	ret := m.ctrl.Call(m, "GetStatePatch", arg0)
+	ret0, _ := ret[0].(*state.Patch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatePatch indicates an expected call of GetStatePatch
+func (mr *MockChainMockRecorder) GetStatePatch(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatePatch", reflect.TypeOf((*MockChain)(nil).GetStatePatch), arg0)
+}
+
 // GetTx mocks base method
 func (m *MockChain) GetTx(arg0 []byte) (*tx.Tx, error) {
 	ret := m.ctrl.Call(m, "GetTx", arg0)
@@ -109,6 +187,20 @@ func (mr *MockChainMockRecorder) GetTx(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTx", reflect.TypeOf((*MockChain)(nil).GetTx), arg0)
 }
 
+// GetTxLocation mocks base method
+func (m *MockChain) GetTxLocation(arg0 []byte) (uint64, int, error) {
+	ret := m.ctrl.Call(m, "GetTxLocation", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTxLocation indicates an expected call of GetTxLocation
+func (mr *MockChainMockRecorder) GetTxLocation(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxLocation", reflect.TypeOf((*MockChain)(nil).GetTxLocation), arg0)
+}
+
 // HasTx mocks base method
 func (m *MockChain) HasTx(arg0 *tx.Tx) (bool, error) {
 	ret := m.ctrl.Call(m, "HasTx", arg0)
@@ -158,6 +250,54 @@ func (mr *MockChainMockRecorder) Push(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockChain)(nil).Push), arg0)
 }
 
+// PushWithPatch mocks base method
+func (m *MockChain) PushWithPatch(arg0 *block.Block, arg1 state.Patch) error {
+	ret := m.ctrl.Call(m, "PushWithPatch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushWithPatch indicates an expected call of PushWithPatch
+func (mr *MockChainMockRecorder) PushWithPatch(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushWithPatch", reflect.TypeOf((*MockChain)(nil).PushWithPatch), arg0, arg1)
+}
+
+// Range mocks base method
+func (m *MockChain) Range(arg0 uint64, arg1 uint64) block.BlockStream {
+	ret := m.ctrl.Call(m, "Range", arg0, arg1)
+	ret0, _ := ret[0].(block.BlockStream)
+	return ret0
+}
+
+// Range indicates an expected call of Range
+func (mr *MockChainMockRecorder) Range(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Range", reflect.TypeOf((*MockChain)(nil).Range), arg0, arg1)
+}
+
+// ReverseIterator mocks base method
+func (m *MockChain) ReverseIterator(arg0 uint64) block.ChainIterator {
+	ret := m.ctrl.Call(m, "ReverseIterator", arg0)
+	ret0, _ := ret[0].(block.ChainIterator)
+	return ret0
+}
+
+// ReverseIterator indicates an expected call of ReverseIterator
+func (mr *MockChainMockRecorder) ReverseIterator(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseIterator", reflect.TypeOf((*MockChain)(nil).ReverseIterator), arg0)
+}
+
+// Rollback mocks base method
+func (m *MockChain) Rollback(arg0 uint64) error {
+	ret := m.ctrl.Call(m, "Rollback", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *MockChainMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockChain)(nil).Rollback), arg0)
+}
+
 // Top mocks base method
 func (m *MockChain) Top() *block.Block {
 	ret := m.ctrl.Call(m, "Top")
@@ -34,6 +34,19 @@ func (m *MockPool) EXPECT() *MockPoolMockRecorder {
 	return m.recorder
 }
 
+// At mocks base method
+func (m *MockPool) At(arg0 int64) (state.Pool, error) {
+	ret := m.ctrl.Call(m, "At", arg0)
+	ret0, _ := ret[0].(state.Pool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// At indicates an expected call of At
+func (mr *MockPoolMockRecorder) At(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "At", reflect.TypeOf((*MockPool)(nil).At), arg0)
+}
+
 // Copy mocks base method
 func (m *MockPool) Copy() state.Pool {
 	ret := m.ctrl.Call(m, "Copy")
@@ -152,3 +165,28 @@ func (m *MockPool) PutHM(arg0, arg1 state.Key, arg2 state.Value) error {
 func (mr *MockPoolMockRecorder) PutHM(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutHM", reflect.TypeOf((*MockPool)(nil).PutHM), arg0, arg1, arg2)
 }
+
+// Revert mocks base method
+func (m *MockPool) Revert(arg0 int64) error {
+	ret := m.ctrl.Call(m, "Revert", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert
+func (mr *MockPoolMockRecorder) Revert(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockPool)(nil).Revert), arg0)
+}
+
+// Root mocks base method
+func (m *MockPool) Root() ([]byte, error) {
+	ret := m.ctrl.Call(m, "Root")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Root indicates an expected call of Root
+func (mr *MockPoolMockRecorder) Root() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Root", reflect.TypeOf((*MockPool)(nil).Root))
+}
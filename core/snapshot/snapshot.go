@@ -0,0 +1,277 @@
+/*
+Package snapshot writes the state of the chain at a confirmed block to a file,
+and loads it into a new node which then syncs on from that block.
+
+A snapshot is the magic, the block, the state values and hash fields, the
+servi records and a trailer of the state root and the sha256 of everything
+before it.
+*/
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/db"
+)
+
+var (
+	ErrBadSnapshot = errors.New("not a snapshot")
+	ErrChecksum    = errors.New("snapshot checksum mismatch")
+	ErrStateRoot   = errors.New("snapshot state root mismatch")
+	ErrNotEmpty    = errors.New("state database or block chain is not empty")
+)
+
+var magic = []byte("IOSTSNAP\x01")
+
+const maxRecordSize = 64 << 20
+
+const (
+	tagValue byte = 's' // state value or hash field
+	tagServi byte = 'v' // servi record
+	tagEnd   byte = 'e' // trailer
+)
+
+type writer struct {
+	w   *bufio.Writer
+	sum hash.Hash
+	err error
+}
+
+func (w *writer) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	w.sum.Write(b)
+	_, w.err = w.w.Write(b)
+}
+
+func (w *writer) writeBytes(b []byte) {
+	var l [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(l[:], uint64(len(b)))
+	w.write(l[:n])
+	w.write(b)
+}
+
+// Export writes the state at block height, read from sdb and from history
+// when height is below the last flushed block, together with the block and
+// the records of servi when it is not nil
+func Export(w io.Writer, sdb, history db.Database, chain block.Chain, servi *tx.ServiPool, height int64) error {
+	blk := chain.GetBlockByNumber(uint64(height))
+	if blk == nil {
+		return fmt.Errorf("block %v not found", height)
+	}
+
+	sw := &writer{w: bufio.NewWriter(w), sum: sha256.New()}
+	sw.write(magic)
+	sw.writeBytes(blk.Encode())
+
+	builder := state.NewStateBuilder(nil)
+	err := state.WalkState(sdb, history, height, func(key, field, value []byte) error {
+		if err := builder.Add(key, field, value); err != nil {
+			return err
+		}
+		sw.write([]byte{tagValue})
+		sw.writeBytes(key)
+		// a nil field marks a plain value, hash fields are never empty
+		sw.writeBytes(field)
+		sw.writeBytes(value)
+		return sw.err
+	})
+	if err != nil {
+		return err
+	}
+
+	if servi != nil {
+		records, err := servi.Dump()
+		if err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(records))
+		for k := range records {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sw.write([]byte{tagServi})
+			sw.writeBytes([]byte(k))
+			sw.writeBytes(records[k])
+		}
+	}
+
+	root, err := builder.Root()
+	if err != nil {
+		return err
+	}
+	sw.write([]byte{tagEnd})
+	sw.writeBytes(root)
+	if sw.err != nil {
+		return sw.err
+	}
+	if _, err := sw.w.Write(sw.sum.Sum(nil)); err != nil {
+		return err
+	}
+	return sw.w.Flush()
+}
+
+type reader struct {
+	r   *bufio.Reader
+	sum hash.Hash
+}
+
+func (r *reader) read(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, err
+	}
+	r.sum.Write(b)
+	return b, nil
+}
+
+func (r *reader) readByte() (byte, error) {
+	b, err := r.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *reader) readBytes() ([]byte, error) {
+	var l uint64
+	var shift uint
+	for i := 0; ; i++ {
+		c, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if i == binary.MaxVarintLen64 {
+			return nil, ErrBadSnapshot
+		}
+		l |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			break
+		}
+		shift += 7
+	}
+	if l > maxRecordSize {
+		return nil, ErrBadSnapshot
+	}
+	return r.read(int(l))
+}
+
+// Import loads a snapshot written by Export into the empty state database
+// sdb, pushes its block on the empty chain and loads the servi records when
+// servi is not nil. Nothing is written unless the checksum, the state root,
+// which must match the one of the block when it carries one, and the block
+// number and hash recorded in the state all verify. It returns the block.
+func Import(r io.Reader, sdb db.Database, chain block.Chain, servi *tx.ServiPool) (*block.Block, error) {
+	keys, err := sdb.Keys(nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 || chain.Length() > 0 {
+		return nil, ErrNotEmpty
+	}
+
+	sr := &reader{r: bufio.NewReader(r), sum: sha256.New()}
+	m, err := sr.read(len(magic))
+	if err != nil || !bytes.Equal(m, magic) {
+		return nil, ErrBadSnapshot
+	}
+	raw, err := sr.readBytes()
+	if err != nil {
+		return nil, err
+	}
+	var blk block.Block
+	if err := blk.Decode(raw); err != nil {
+		return nil, err
+	}
+
+	builder := state.NewStateBuilder(sdb)
+	records := make(map[string][]byte)
+	var blockNum, blockHash []byte
+	var root []byte
+	for root == nil {
+		tag, err := sr.readByte()
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case tagValue:
+			var kfv [3][]byte
+			for i := range kfv {
+				if kfv[i], err = sr.readBytes(); err != nil {
+					return nil, err
+				}
+			}
+			key, field, value := kfv[0], kfv[1], kfv[2]
+			if len(field) == 0 {
+				field = nil
+				switch string(key) {
+				case "BlockNum":
+					blockNum = value
+				case "BlockHash":
+					blockHash = value
+				}
+			}
+			if err := builder.Add(key, field, value); err != nil {
+				return nil, err
+			}
+		case tagServi:
+			k, err := sr.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			if records[string(k)], err = sr.readBytes(); err != nil {
+				return nil, err
+			}
+		case tagEnd:
+			if root, err = sr.readBytes(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrBadSnapshot
+		}
+	}
+
+	sum := sr.sum.Sum(nil)
+	expect := make([]byte, len(sum))
+	if _, err := io.ReadFull(sr.r, expect); err != nil || !bytes.Equal(sum, expect) {
+		return nil, ErrChecksum
+	}
+
+	computed, err := builder.Root()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(computed, root) || (len(blk.Head.StateRoot) > 0 && !bytes.Equal(computed, blk.Head.StateRoot)) {
+		return nil, ErrStateRoot
+	}
+	if !bytes.Equal(blockNum, state.EncodeValue(state.MakeVInt(int(blk.Head.Number)))) ||
+		!bytes.Equal(blockHash, state.EncodeValue(state.MakeVByte(blk.HeadHash()))) {
+		return nil, fmt.Errorf("snapshot state is not at block %v", blk.Head.Number)
+	}
+
+	if err := builder.Commit(); err != nil {
+		return nil, err
+	}
+	if servi != nil && len(records) > 0 {
+		if err := servi.Load(records); err != nil {
+			return nil, err
+		}
+	}
+	if err := chain.Push(&blk); err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
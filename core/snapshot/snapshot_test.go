@@ -0,0 +1,86 @@
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/mocks"
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/iost-official/Go-IOS-Protocol/db"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSnapshot(t *testing.T) {
+	Convey("Test of snapshot export and import", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		state.PatchDb, _ = db.NewMemDatabase()
+		defer func() { state.PatchDb = nil }()
+		pool := state.NewPool(state.NewDatabase(mdb))
+
+		pool.Put("a", state.MakeVInt(1))
+		pool.PutHM("iost", "x", state.DecimalFromInt(10))
+		pool.PutHM("iost", "y", state.DecimalFromInt(5))
+		root1, err := pool.Root()
+		So(err, ShouldBeNil)
+		blk1 := &block.Block{Head: block.BlockHead{Number: 1, Time: 1, StateRoot: root1}}
+		pool.Put("BlockNum", state.MakeVInt(1))
+		pool.Put("BlockHash", state.MakeVByte(blk1.HeadHash()))
+		So(pool.Flush(), ShouldBeNil)
+
+		pool.Delete("a")
+		pool.PutHM("iost", "x", state.DecimalFromInt(20))
+		pool.Put("b", state.MakeVString("later"))
+		pool.Put("BlockNum", state.MakeVInt(2))
+		So(pool.Flush(), ShouldBeNil)
+
+		ctl := gomock.NewController(t)
+		chain := core_mock.NewMockChain(ctl)
+		chain.EXPECT().GetBlockByNumber(uint64(1)).AnyTimes().Return(blk1)
+
+		var buf bytes.Buffer
+		So(Export(&buf, mdb, state.PatchDb, chain, nil, 1), ShouldBeNil)
+
+		newChain := core_mock.NewMockChain(ctl)
+		newChain.EXPECT().Length().AnyTimes().Return(uint64(0))
+
+		Convey("import restores the state of the block", func() {
+			var pushed *block.Block
+			newChain.EXPECT().Push(gomock.Any()).Do(func(b *block.Block) { pushed = b }).Return(nil)
+			mdb2, _ := db.NewMemDatabase()
+			blk, err := Import(bytes.NewReader(buf.Bytes()), mdb2, newChain, nil)
+			So(err, ShouldBeNil)
+			So(blk.HeadHash(), ShouldResemble, blk1.HeadHash())
+			So(pushed, ShouldNotBeNil)
+
+			pool2 := state.NewPool(state.NewDatabase(mdb2))
+			r, err := pool2.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, root1)
+			v, _ := pool2.Get("a")
+			So(v.EncodeString(), ShouldEqual, "i1")
+			v, _ = pool2.GetHM("iost", "x")
+			So(v.EncodeString(), ShouldEqual, "d10")
+			So(pool2.Has("b"), ShouldBeFalse)
+
+			_, err = Import(bytes.NewReader(buf.Bytes()), mdb2, newChain, nil)
+			So(err, ShouldEqual, ErrNotEmpty)
+		})
+
+		Convey("a corrupted snapshot is rejected before writing", func() {
+			b := append([]byte{}, buf.Bytes()...)
+			b[len(b)-40] ^= 1
+			mdb2, _ := db.NewMemDatabase()
+			_, err := Import(bytes.NewReader(b), mdb2, newChain, nil)
+			So(err, ShouldNotBeNil)
+			keys, _ := mdb2.Keys(nil)
+			So(len(keys), ShouldEqual, 0)
+		})
+
+		Convey("a block above the state fails", func() {
+			chain.EXPECT().GetBlockByNumber(uint64(3)).Return(&block.Block{Head: block.BlockHead{Number: 3}})
+			So(Export(&bytes.Buffer{}, mdb, state.PatchDb, chain, nil, 3), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// valueCodecVersion leads every binary encoded value. It can never start a
+// value in the legacy text format, whose first byte is a letter or '{', so
+// DecodeValue still reads databases written before the binary codec.
+const valueCodecVersion byte = 1
+
+var (
+	errCorruptValue = errors.New("decoding value: length mismatch")
+	errEmptyValue   = errors.New("decoding value: empty input")
+)
+
+// EncodeValue encodes v with the binary value codec
+func EncodeValue(v Value) []byte {
+	raw := toRaw(v)
+	b, err := raw.Marshal(nil)
+	if err != nil {
+		panic(err)
+	}
+	return append([]byte{valueCodecVersion}, b...)
+}
+
+// DecodeValue decodes a value written by EncodeValue, or by EncodeString in
+// the legacy text format
+func DecodeValue(b []byte) (v Value, err error) {
+	if len(b) == 0 {
+		return nil, errEmptyValue
+	}
+	if b[0] != valueCodecVersion {
+		return ParseValue(string(b))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("decoding value: %v", r)
+		}
+	}()
+	var raw ValueRaw
+	n, err := raw.Unmarshal(b[1:])
+	if err != nil {
+		return nil, err
+	}
+	if n != uint64(len(b)-1) {
+		return nil, errCorruptValue
+	}
+	return fromRaw(&raw)
+}
+
+func toRaw(v Value) ValueRaw {
+	raw := ValueRaw{t: uint8(v.Type())}
+	switch vv := v.(type) {
+	case *VBool:
+		if vv.val {
+			raw.val = []byte{1}
+		} else {
+			raw.val = []byte{0}
+		}
+	case *VInt:
+		raw.val = make([]byte, 8)
+		binary.BigEndian.PutUint64(raw.val, uint64(vv.int))
+	case *VFloat:
+		raw.val = make([]byte, 8)
+		binary.BigEndian.PutUint64(raw.val, math.Float64bits(vv.float64))
+	case *VString:
+		raw.val = []byte(vv.string)
+	case *VBytes:
+		raw.val = vv.val
+	case *VDecimal:
+		// a sign byte, 1 for negative, then the big endian magnitude
+		raw.val = append([]byte{0}, vv.units.Bytes()...)
+		if vv.units.Sign() < 0 {
+			raw.val[0] = 1
+		}
+	case *VArray:
+		raw.val = encodeList(vv.vals)
+	case *VStack:
+		raw.val = encodeList(vv.vals)
+	case *VQueue:
+		raw.val = encodeList(vv.vals)
+	case *VMap:
+		vv.mutex.RLock()
+		mr := MapRaw{
+			keys: make([]string, 0, len(vv.m)),
+			vals: make([][]byte, 0, len(vv.m)),
+		}
+		for k := range vv.m {
+			mr.keys = append(mr.keys, string(k))
+		}
+		sort.Strings(mr.keys)
+		for _, k := range mr.keys {
+			fr := toRaw(vv.m[Key(k)])
+			b, err := fr.Marshal(nil)
+			if err != nil {
+				panic(err)
+			}
+			mr.vals = append(mr.vals, b)
+		}
+		vv.mutex.RUnlock()
+		b, err := mr.Marshal(nil)
+		if err != nil {
+			panic(err)
+		}
+		raw.val = b
+	}
+	return raw
+}
+
+func fromRaw(raw *ValueRaw) (Value, error) {
+	switch Type(raw.t) {
+	case Nil:
+		return VNil, nil
+	case Delete:
+		return VDelete, nil
+	case Bool:
+		if len(raw.val) != 1 {
+			return nil, fmt.Errorf("decoding bool: bad length %v", len(raw.val))
+		}
+		return MakeVBool(raw.val[0] != 0), nil
+	case Int:
+		if len(raw.val) != 8 {
+			return nil, fmt.Errorf("decoding int: bad length %v", len(raw.val))
+		}
+		return MakeVInt(int(int64(binary.BigEndian.Uint64(raw.val)))), nil
+	case Float:
+		if len(raw.val) != 8 {
+			return nil, fmt.Errorf("decoding float: bad length %v", len(raw.val))
+		}
+		return MakeVFloat(math.Float64frombits(binary.BigEndian.Uint64(raw.val))), nil
+	case String:
+		return MakeVString(string(raw.val)), nil
+	case Bytes:
+		return MakeVByte(raw.val), nil
+	case Map:
+		var mr MapRaw
+		n, err := mr.Unmarshal(raw.val)
+		if err != nil {
+			return nil, err
+		}
+		if n != uint64(len(raw.val)) {
+			return nil, errCorruptValue
+		}
+		if len(mr.keys) != len(mr.vals) {
+			return nil, fmt.Errorf("decoding map: %v keys and %v values", len(mr.keys), len(mr.vals))
+		}
+		m := MakeVMap(nil)
+		for i, k := range mr.keys {
+			var fr ValueRaw
+			n, err := fr.Unmarshal(mr.vals[i])
+			if err != nil {
+				return nil, err
+			}
+			if n != uint64(len(mr.vals[i])) {
+				return nil, errCorruptValue
+			}
+			v, err := fromRaw(&fr)
+			if err != nil {
+				return nil, err
+			}
+			m.m[Key(k)] = v
+		}
+		return m, nil
+	case Decimal:
+		if len(raw.val) == 0 || raw.val[0] > 1 {
+			return nil, fmt.Errorf("decoding decimal: bad sign")
+		}
+		units := new(big.Int).SetBytes(raw.val[1:])
+		if raw.val[0] == 1 {
+			units.Neg(units)
+		}
+		return &VDecimal{units: units}, nil
+	case Array:
+		vals, err := decodeList(raw.val)
+		if err != nil {
+			return nil, err
+		}
+		return &VArray{vals: vals}, nil
+	case Stack:
+		vals, err := decodeList(raw.val)
+		if err != nil {
+			return nil, err
+		}
+		return MakeVStack(vals), nil
+	case Queue:
+		vals, err := decodeList(raw.val)
+		if err != nil {
+			return nil, err
+		}
+		return MakeVQueue(vals), nil
+	}
+	return nil, fmt.Errorf("decoding value: unknown type %v", raw.t)
+}
+
+func encodeList(vals []Value) []byte {
+	lr := ListRaw{vals: make([][]byte, 0, len(vals))}
+	for _, v := range vals {
+		er := toRaw(v)
+		b, err := er.Marshal(nil)
+		if err != nil {
+			panic(err)
+		}
+		lr.vals = append(lr.vals, b)
+	}
+	b, err := lr.Marshal(nil)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func decodeList(b []byte) ([]Value, error) {
+	var lr ListRaw
+	n, err := lr.Unmarshal(b)
+	if err != nil {
+		return nil, err
+	}
+	if n != uint64(len(b)) {
+		return nil, errCorruptValue
+	}
+	vals := make([]Value, 0, len(lr.vals))
+	for _, eb := range lr.vals {
+		var er ValueRaw
+		n, err := er.Unmarshal(eb)
+		if err != nil {
+			return nil, err
+		}
+		if n != uint64(len(eb)) {
+			return nil, errCorruptValue
+		}
+		v, err := fromRaw(&er)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
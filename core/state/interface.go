@@ -9,6 +9,9 @@ type Pool interface {
 	GetPatch() Patch
 	Flush() error
 	MergeParent() (Pool, error)
+	Root() ([]byte, error)
+	At(height int64) (Pool, error)
+	Revert(height int64) error
 
 	Put(key Key, value Value)
 	Get(key Key) (Value, error)
@@ -2,7 +2,9 @@ package state
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"sync"
@@ -10,11 +12,16 @@ import (
 
 type Key string
 
+var ErrIndexOutOfRange = errors.New("index out of range")
+
 type Value interface {
 	Type() Type
 	EncodeString() string
 }
 
+// Merge applies the patched value b on a. Fields of a map patch are merged
+// into the map, any other value, arrays, stacks and queues included, replaces
+// a as a whole.
 func Merge(a, b Value) Value {
 
 	switch {
@@ -55,6 +62,7 @@ const (
 	Map
 	Stack
 	Queue
+	Decimal
 )
 
 func ParseValue(s string) (Value, error) {
@@ -64,10 +72,12 @@ func ParseValue(s string) (Value, error) {
 	switch {
 	case s == "nil":
 		return VNil, nil
+	case s == "delete":
+		return VDelete, nil
 	case s == "true":
 		return VTrue, nil
 
-	case s == "false ":
+	case s == "false", s == "false ":
 		return VFalse, nil
 	case strings.HasPrefix(s, "i"):
 		i, err := strconv.Atoi(s1)
@@ -90,6 +100,8 @@ func ParseValue(s string) (Value, error) {
 		return MakeVByte(b), nil
 	case strings.HasPrefix(s, "s"):
 		return MakeVString(s[1:]), nil
+	case strings.HasPrefix(s, "d"):
+		return ParseDecimal(s1)
 	case strings.HasPrefix(s, "{"):
 		ss := strings.Split(s1, ",")
 		if len(ss) <= 0 {
@@ -285,3 +297,315 @@ func (v *VMap) Get(key Key) Value {
 func (v *VMap) Map() map[Key]Value {
 	return v.m
 }
+
+// copy returns a VMap with its own map holding the fields of v
+func (v *VMap) copy() *VMap {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	nm := make(map[Key]Value, len(v.m))
+	for k, val := range v.m {
+		nm[k] = val
+	}
+	return MakeVMap(nm)
+}
+
+func encodeListString(prefix string, vals []Value) string {
+	str := prefix
+	for _, val := range vals {
+		str += val.EncodeString() + ","
+	}
+	return str
+}
+
+func copyList(vals []Value) []Value {
+	return append(make([]Value, 0, len(vals)), vals...)
+}
+
+// VArray is an array of fixed length, unset elements are VNil
+type VArray struct {
+	vals []Value
+}
+
+func MakeVArray(length int) *VArray {
+	vals := make([]Value, length)
+	for i := range vals {
+		vals[i] = VNil
+	}
+	return &VArray{
+		vals: vals,
+	}
+}
+
+func (v *VArray) Type() Type {
+	return Array
+}
+func (v *VArray) EncodeString() string {
+	return encodeListString("[", v.vals)
+}
+
+func (v *VArray) Len() int {
+	return len(v.vals)
+}
+
+func (v *VArray) Get(i int) Value {
+	if i < 0 || i >= len(v.vals) {
+		return VNil
+	}
+	return v.vals[i]
+}
+
+func (v *VArray) Set(i int, value Value) error {
+	if i < 0 || i >= len(v.vals) {
+		return ErrIndexOutOfRange
+	}
+	v.vals[i] = value
+	return nil
+}
+
+func (v *VArray) Values() []Value {
+	return v.vals
+}
+
+func (v *VArray) Copy() *VArray {
+	return &VArray{vals: copyList(v.vals)}
+}
+
+// VStack is a last in first out list, Values lists it from bottom to top
+type VStack struct {
+	vals []Value
+}
+
+func MakeVStack(vals []Value) *VStack {
+	return &VStack{
+		vals: vals,
+	}
+}
+
+func (v *VStack) Type() Type {
+	return Stack
+}
+func (v *VStack) EncodeString() string {
+	return encodeListString("<", v.vals)
+}
+
+func (v *VStack) Len() int {
+	return len(v.vals)
+}
+
+func (v *VStack) Push(value Value) {
+	v.vals = append(v.vals, value)
+}
+
+// Pop removes and returns the top of the stack, VNil when it is empty
+func (v *VStack) Pop() Value {
+	if len(v.vals) == 0 {
+		return VNil
+	}
+	top := v.vals[len(v.vals)-1]
+	v.vals = v.vals[:len(v.vals)-1]
+	return top
+}
+
+func (v *VStack) Top() Value {
+	if len(v.vals) == 0 {
+		return VNil
+	}
+	return v.vals[len(v.vals)-1]
+}
+
+func (v *VStack) Values() []Value {
+	return v.vals
+}
+
+func (v *VStack) Copy() *VStack {
+	return &VStack{vals: copyList(v.vals)}
+}
+
+// VQueue is a first in first out list, Values lists it from front to back
+type VQueue struct {
+	vals []Value
+}
+
+func MakeVQueue(vals []Value) *VQueue {
+	return &VQueue{
+		vals: vals,
+	}
+}
+
+func (v *VQueue) Type() Type {
+	return Queue
+}
+func (v *VQueue) EncodeString() string {
+	return encodeListString("(", v.vals)
+}
+
+func (v *VQueue) Len() int {
+	return len(v.vals)
+}
+
+func (v *VQueue) Push(value Value) {
+	v.vals = append(v.vals, value)
+}
+
+// Pop removes and returns the front of the queue, VNil when it is empty
+func (v *VQueue) Pop() Value {
+	if len(v.vals) == 0 {
+		return VNil
+	}
+	front := v.vals[0]
+	v.vals = v.vals[1:]
+	return front
+}
+
+func (v *VQueue) Front() Value {
+	if len(v.vals) == 0 {
+		return VNil
+	}
+	return v.vals[0]
+}
+
+func (v *VQueue) Values() []Value {
+	return v.vals
+}
+
+func (v *VQueue) Copy() *VQueue {
+	return &VQueue{vals: copyList(v.vals)}
+}
+
+// DecimalPlaces is the number of fractional digits of a VDecimal
+const DecimalPlaces = 8
+
+var (
+	decimalUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(DecimalPlaces), nil)
+
+	ErrInvalidDecimal = errors.New("invalid decimal")
+)
+
+// VDecimal is an exact amount of tokens, a fixed point number with
+// DecimalPlaces fractional digits kept as an integer count of its smallest unit
+type VDecimal struct {
+	units *big.Int
+}
+
+// MakeVDecimal returns the decimal of units times 10^-DecimalPlaces
+func MakeVDecimal(units *big.Int) *VDecimal {
+	return &VDecimal{
+		units: new(big.Int).Set(units),
+	}
+}
+
+// DecimalFromInt returns the decimal of i whole tokens
+func DecimalFromInt(i int64) *VDecimal {
+	return &VDecimal{
+		units: new(big.Int).Mul(big.NewInt(i), decimalUnit),
+	}
+}
+
+// DecimalFromFloat returns f rounded to DecimalPlaces fractional digits
+func DecimalFromFloat(f float64) *VDecimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', DecimalPlaces, 64))
+	if err != nil {
+		return DecimalFromInt(0)
+	}
+	return d
+}
+
+// ParseDecimal parses a decimal written as [-]digits[.digits], with at most
+// DecimalPlaces fractional digits
+func ParseDecimal(s string) (*VDecimal, error) {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" || len(fracPart) > DecimalPlaces || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, ErrInvalidDecimal
+	}
+	units, ok := new(big.Int).SetString(intPart+fracPart+strings.Repeat("0", DecimalPlaces-len(fracPart)), 10)
+	if !ok {
+		return nil, ErrInvalidDecimal
+	}
+	if neg {
+		units.Neg(units)
+	}
+	return &VDecimal{units: units}, nil
+}
+
+// MustParseDecimal is ParseDecimal that panics on an invalid input
+func MustParseDecimal(s string) *VDecimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *VDecimal) Type() Type {
+	return Decimal
+}
+func (v *VDecimal) EncodeString() string {
+	return "d" + v.String()
+}
+
+// String formats v without trailing fractional zeros
+func (v *VDecimal) String() string {
+	abs := new(big.Int).Abs(v.units)
+	q, r := new(big.Int).QuoRem(abs, decimalUnit, new(big.Int))
+	str := q.String()
+	if r.Sign() != 0 {
+		frac := r.String()
+		frac = strings.Repeat("0", DecimalPlaces-len(frac)) + frac
+		str += "." + strings.TrimRight(frac, "0")
+	}
+	if v.units.Sign() < 0 {
+		str = "-" + str
+	}
+	return str
+}
+
+// Units returns v as a count of its smallest unit
+func (v *VDecimal) Units() *big.Int {
+	return new(big.Int).Set(v.units)
+}
+
+func (v *VDecimal) Add(b *VDecimal) *VDecimal {
+	return &VDecimal{units: new(big.Int).Add(v.units, b.units)}
+}
+
+func (v *VDecimal) Sub(b *VDecimal) *VDecimal {
+	return &VDecimal{units: new(big.Int).Sub(v.units, b.units)}
+}
+
+func (v *VDecimal) Neg() *VDecimal {
+	return &VDecimal{units: new(big.Int).Neg(v.units)}
+}
+
+func (v *VDecimal) MulUint64(n uint64) *VDecimal {
+	return &VDecimal{units: new(big.Int).Mul(v.units, new(big.Int).SetUint64(n))}
+}
+
+func (v *VDecimal) Cmp(b *VDecimal) int {
+	return v.units.Cmp(b.units)
+}
+
+func (v *VDecimal) Sign() int {
+	return v.units.Sign()
+}
+
+// Float64 returns the nearest float64, for display and ranking only
+func (v *VDecimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(v.units, decimalUnit).Float64()
+	return f
+}
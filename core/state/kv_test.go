@@ -5,6 +5,7 @@ import (
 
 	"fmt"
 
+	"github.com/iost-official/Go-IOS-Protocol/db"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -40,3 +41,193 @@ func TestKV(t *testing.T) {
 		})
 	})
 }
+
+func TestValueCodec(t *testing.T) {
+	Convey("Test of binary value codec", t, func() {
+		values := []Value{
+			VNil, VDelete, VTrue, VFalse,
+			MakeVInt(-42), MakeVFloat(3.14),
+			MakeVString("a,b:c"), MakeVByte([]byte{0, 1, 2}),
+		}
+		for _, v := range values {
+			v2, err := DecodeValue(EncodeValue(v))
+			So(err, ShouldBeNil)
+			So(v2.Type(), ShouldEqual, v.Type())
+			So(v2.EncodeString(), ShouldEqual, v.EncodeString())
+		}
+
+		inner := MakeVMap(map[Key]Value{Key("x:y"): MakeVString("1,2")})
+		m := MakeVMap(map[Key]Value{Key("a,b"): MakeVInt(1), Key("in"): inner})
+		v, err := DecodeValue(EncodeValue(m))
+		So(err, ShouldBeNil)
+		So(v.(*VMap).Get("a,b").(*VInt).ToInt(), ShouldEqual, 1)
+		So(v.(*VMap).Get("in").(*VMap).Get("x:y").EncodeString(), ShouldEqual, "s1,2")
+		So(EncodeValue(m), ShouldResemble, EncodeValue(v))
+
+		Convey("legacy text is still readable", func() {
+			v, err := DecodeValue([]byte("i123"))
+			So(err, ShouldBeNil)
+			So(v.(*VInt).ToInt(), ShouldEqual, 123)
+			v, err = DecodeValue([]byte("false"))
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, VFalse)
+		})
+
+		Convey("truncated input fails", func() {
+			b := EncodeValue(m)
+			_, err := DecodeValue(b[:len(b)-3])
+			So(err, ShouldNotBeNil)
+		})
+		Convey("empty input fails", func() {
+			_, err := DecodeValue(nil)
+			So(err, ShouldNotBeNil)
+			_, err = DecodeValue([]byte{})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCollections(t *testing.T) {
+	Convey("Test of array, stack and queue", t, func() {
+		arr := MakeVArray(3)
+		So(arr.Set(1, MakeVInt(5)), ShouldBeNil)
+		So(arr.Set(3, MakeVInt(5)), ShouldEqual, ErrIndexOutOfRange)
+		So(arr.Get(0), ShouldEqual, VNil)
+
+		st := MakeVStack(nil)
+		st.Push(MakeVString("a"))
+		st.Push(MakeVString("b"))
+		st2 := st.Copy()
+		So(st2.Pop().EncodeString(), ShouldEqual, "sb")
+		So(st.Len(), ShouldEqual, 2)
+		So(st.Top().EncodeString(), ShouldEqual, "sb")
+
+		q := MakeVQueue([]Value{MakeVInt(1), MakeVInt(2)})
+		So(q.Pop().EncodeString(), ShouldEqual, "i1")
+		So(q.Front().EncodeString(), ShouldEqual, "i2")
+
+		Convey("codec round trip", func() {
+			for _, v := range []Value{arr, st, q, MakeVStack(nil)} {
+				v2, err := DecodeValue(EncodeValue(v))
+				So(err, ShouldBeNil)
+				So(v2.Type(), ShouldEqual, v.Type())
+				So(v2.EncodeString(), ShouldEqual, v.EncodeString())
+			}
+			b := EncodeValue(arr)
+			_, err := DecodeValue(b[:len(b)-1])
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("patched collection replaces the old one", func() {
+			So(Merge(MakeVStack([]Value{MakeVInt(9)}), st).EncodeString(), ShouldEqual, st.EncodeString())
+		})
+	})
+}
+
+func TestDecimal(t *testing.T) {
+	Convey("Test of decimal", t, func() {
+		a := MustParseDecimal("0.1")
+		b := MustParseDecimal("0.2")
+		So(a.Add(b).String(), ShouldEqual, "0.3")
+		So(a.Sub(b).String(), ShouldEqual, "-0.1")
+		So(MustParseDecimal("12").MulUint64(3).String(), ShouldEqual, "36")
+		So(DecimalFromFloat(0.01).String(), ShouldEqual, "0.01")
+
+		big := MustParseDecimal("123456789012345678901234567890.12345678")
+		So(big.Add(DecimalFromInt(1)).Sub(DecimalFromInt(1)).Cmp(big), ShouldEqual, 0)
+
+		for _, s := range []string{"", ".5", "1.123456789", "1e5", "--1"} {
+			_, err := ParseDecimal(s)
+			So(err, ShouldEqual, ErrInvalidDecimal)
+		}
+
+		Convey("encoding round trip", func() {
+			for _, d := range []*VDecimal{big, big.Neg(), DecimalFromInt(0)} {
+				v, err := DecodeValue(EncodeValue(d))
+				So(err, ShouldBeNil)
+				So(v.(*VDecimal).Cmp(d), ShouldEqual, 0)
+				v, err = ParseValue(d.EncodeString())
+				So(err, ShouldBeNil)
+				So(v.(*VDecimal).Cmp(d), ShouldEqual, 0)
+			}
+		})
+	})
+}
+
+func TestMigrateDatabase(t *testing.T) {
+	Convey("Test of migrating legacy values", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		mdb.Put([]byte("a"), []byte("i1"))
+		mdb.PutHM([]byte("iost"), []byte("x"), []byte("f1.000000000000000e+00"))
+		mdb.Put([]byte("BlockNum"), []byte("i7"))
+		mdb.PutHM([]byte("iost"), []byte("y"), EncodeValue(MakeVFloat(0.1)))
+
+		n, err := MigrateDatabase(mdb)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 4)
+
+		pool := NewPool(NewDatabase(mdb))
+		v, err := pool.GetHM("iost", "x")
+		So(err, ShouldBeNil)
+		So(v.(*VDecimal).String(), ShouldEqual, "1")
+		v, err = pool.GetHM("iost", "y")
+		So(err, ShouldBeNil)
+		So(v.(*VDecimal).String(), ShouldEqual, "0.1")
+		raw, _ := mdb.Get([]byte("a"))
+		So(raw, ShouldResemble, EncodeValue(MakeVInt(1)))
+
+		mdb2, _ := db.NewMemDatabase()
+		expect := NewPool(NewDatabase(mdb2))
+		expect.Put("a", MakeVInt(1))
+		expect.PutHM("iost", "x", DecimalFromInt(1))
+		expect.PutHM("iost", "y", MustParseDecimal("0.1"))
+		r1, err := expect.Root()
+		So(err, ShouldBeNil)
+		r2, err := pool.Root()
+		So(err, ShouldBeNil)
+		So(r2, ShouldResemble, r1)
+
+		n, err = MigrateDatabase(mdb)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 0)
+	})
+}
+
+func TestRebuildRoot(t *testing.T) {
+	Convey("Test of rebuilding the trie of an existing database", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		mdb.Put([]byte("BlockNum"), EncodeValue(MakeVInt(7)))
+		ok, err := RebuildRoot(mdb)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+
+		mdb.Put([]byte("a"), EncodeValue(MakeVInt(1)))
+		mdb.PutHM([]byte("iost"), []byte("x"), EncodeValue(DecimalFromInt(1)))
+		ok, err = RebuildRoot(mdb)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+
+		mdb2, _ := db.NewMemDatabase()
+		expect := NewPool(NewDatabase(mdb2))
+		expect.Put("a", MakeVInt(1))
+		expect.PutHM("iost", "x", DecimalFromInt(1))
+		r1, err := expect.Root()
+		So(err, ShouldBeNil)
+		r2, err := NewPool(NewDatabase(mdb)).Root()
+		So(err, ShouldBeNil)
+		So(r2, ShouldResemble, r1)
+
+		ok, err = RebuildRoot(mdb)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+
+		// a root of the former trie, whose inner nodes were two bare hashes
+		mdb.Put(trieRootKey, hashNode(append(r1, r1...)))
+		ok, err = RebuildRoot(mdb)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		r2, err = NewPool(NewDatabase(mdb)).Root()
+		So(err, ShouldBeNil)
+		So(r2, ShouldResemble, r1)
+	})
+}
@@ -0,0 +1,159 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+)
+
+// balanceKeys are the hashes whose fields hold token amounts
+var balanceKeys = []string{"iost", "iost-contract"}
+
+// MigrateDatabase rewrites every value of d still in the legacy text format
+// with the binary codec, converts float balances to VDecimal, and rebuilds
+// the state trie from the converted values since leaves hash the binary
+// encoding. It returns the number of converted values.
+func MigrateDatabase(d db.Database) (int, error) {
+	keys, err := d.Keys(nil)
+	if err != nil {
+		return 0, err
+	}
+	hdb, isHash := d.(HashDatabase)
+
+	batch := d.NewBatch()
+	leaves := make(leafSet)
+	count := 0
+	convert := func(raw []byte, balance bool) (Value, []byte, error) {
+		v, err := DecodeValue(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		if f, ok := v.(*VFloat); ok && balance {
+			v = DecimalFromFloat(f.ToFloat64())
+		} else if len(raw) > 0 && raw[0] == valueCodecVersion {
+			return v, nil, nil
+		}
+		count++
+		return v, EncodeValue(v), nil
+	}
+
+	for _, key := range keys {
+		if len(key) > 0 && key[0] == 0 {
+			continue
+		}
+		excluded := trieExcluded(Key(key))
+		if isHash {
+			t, err := hdb.Type(string(key))
+			if err != nil {
+				return 0, err
+			}
+			if t == "hash" {
+				fields, err := hdb.GetAll(string(key))
+				if err != nil {
+					return 0, err
+				}
+				for f, raw := range fields {
+					v, enc, err := convert([]byte(raw), isBalance(string(key)))
+					if err != nil {
+						return 0, err
+					}
+					if enc != nil {
+						if err := batch.PutHM(key, []byte(f), enc); err != nil {
+							return 0, err
+						}
+					}
+					if !excluded {
+						leaves.set(fieldPath(Key(key), Key(f)), leafHash(v))
+					}
+				}
+				continue
+			}
+		}
+		raw, err := d.Get(key)
+		if err != nil {
+			return 0, err
+		}
+		v, enc, err := convert(raw, isBalanceField(key))
+		if err != nil {
+			return 0, err
+		}
+		if enc != nil {
+			if err := batch.Put(key, enc); err != nil {
+				return 0, err
+			}
+		}
+		// a hash field stored as key+0x00+field lands on its field path
+		if !excluded {
+			leaves.set(leafPath(Key(key)), leafHash(v))
+		}
+	}
+
+	t := newTrieUpdate(missingNode)
+	root, err := t.update(EmptyRoot(), 0, leaves.sorted())
+	if err != nil {
+		return 0, err
+	}
+	b := &Batch{batch: batch}
+	for h, node := range t.nodes {
+		if err := b.putNode([]byte(h), node); err != nil {
+			return 0, err
+		}
+	}
+	if err := b.putRoot(root); err != nil {
+		return 0, err
+	}
+	return count, batch.Write()
+}
+
+// RebuildRoot builds the state trie of d when d holds state but no state root, as
+// databases written before the trie do, or a root whose node is not in the
+// current trie format, so that roots computed on top of it cover the state
+// already there. The values are migrated on the way. It reports whether the
+// trie was rebuilt.
+func RebuildRoot(d db.Database) (bool, error) {
+	ok, err := d.Has(trieRootKey)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		sd := NewDatabase(d)
+		root, err := sd.root()
+		if err != nil || isEmpty(root) {
+			return false, err
+		}
+		if node, err := sd.getNode(root); err == nil {
+			if _, _, _, err := decodeNode(node); err == nil {
+				return false, nil
+			}
+		}
+		_, err = MigrateDatabase(d)
+		return err == nil, err
+	}
+	keys, err := d.Keys(nil)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		if len(key) > 0 && key[0] != 0 && !trieExcluded(Key(key)) {
+			_, err := MigrateDatabase(d)
+			return err == nil, err
+		}
+	}
+	return false, nil
+}
+
+func isBalance(key string) bool {
+	for _, k := range balanceKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// isBalanceField reports whether key is a field of a balance hash stored by
+// a database without hashes, as key+0x00+field
+func isBalanceField(key []byte) bool {
+	i := bytes.IndexByte(key, 0)
+	return i > 0 && isBalance(string(key[:i]))
+}
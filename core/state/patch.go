@@ -1,5 +1,10 @@
 package state
 
+import (
+	"crypto/sha256"
+	"sort"
+)
+
 type Patch struct {
 	m map[Key]Value
 }
@@ -28,14 +33,29 @@ func (p *Patch) Length() int {
 	return len(p.m)
 }
 
+// copy returns a patch that can be changed without changing p, hash maps included
+func (p *Patch) copy() Patch {
+	m := make(map[Key]Value, len(p.m))
+	for k, v := range p.m {
+		if vm, ok := v.(*VMap); ok {
+			v = vm.copy()
+		}
+		m[k] = v
+	}
+	return Patch{m}
+}
+
 func (p *Patch) Encode() []byte {
 	pr := PatchRaw{
 		keys: make([]string, 0),
 		vals: make([][]byte, 0),
 	}
-	for k, v := range p.m {
+	for k := range p.m {
 		pr.keys = append(pr.keys, string(k))
-		pr.vals = append(pr.vals, []byte(v.EncodeString()))
+	}
+	sort.Strings(pr.keys)
+	for _, k := range pr.keys {
+		pr.vals = append(pr.vals, EncodeValue(p.m[Key(k)]))
 	}
 	b, err := pr.Marshal(nil)
 	if err != nil {
@@ -49,10 +69,13 @@ func (p *Patch) Decode(bin []byte) error {
 	if err != nil {
 		return err
 	}
+	if p.m == nil {
+		p.m = make(map[Key]Value)
+	}
 
 	for i, k := range pr.keys {
 		var v Value
-		v, err = ParseValue(string(pr.vals[i]))
+		v, err = DecodeValue(pr.vals[i])
 		if err != nil {
 			return err
 
@@ -61,6 +84,28 @@ func (p *Patch) Decode(bin []byte) error {
 	}
 	return nil
 }
-func (p *Patch) Hash() []byte {
+
+// Apply writes every entry of the patch into pool
+func (p *Patch) Apply(pool Pool) error {
+	for k, v := range p.m {
+		switch {
+		case v == VDelete:
+			pool.Delete(k)
+		case v.Type() == Map:
+			for f, fv := range v.(*VMap).m {
+				if err := pool.PutHM(k, f, fv); err != nil {
+					return err
+				}
+			}
+		default:
+			pool.Put(k, v)
+		}
+	}
 	return nil
 }
+
+// Hash digests the patch, keys and map fields are encoded in sorted order
+func (p *Patch) Hash() []byte {
+	h := sha256.Sum256(p.Encode())
+	return h[:]
+}
@@ -1,11 +1,381 @@
 package state
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"sync"
 
 	"github.com/iost-official/Go-IOS-Protocol/db"
 )
 
-var PatchDb *db.LDBDatabase
+// PatchDb keeps the history of the state database. Every flush that commits
+// a block, i.e. that writes "BlockNum", records the value each written key
+// had before, indexed by key and block number, so that the state as of any
+// block since the history was enabled can be read back with Pool.At.
+var PatchDb db.Database
 
 var o sync.Once
+
+var (
+	ErrHistoryDisabled = errors.New("state history is not enabled")
+	ErrHistoryPruned   = errors.New("state history is not available at this height")
+	ErrReadOnly        = errors.New("historical state is read only")
+)
+
+var (
+	historyPrefix   = []byte("v")
+	historyBeginKey = []byte("\x00begin")
+)
+
+// PatchDbInstance opens PatchDb under LdbPath
+func PatchDbInstance() error {
+	var err error
+	o.Do(func() {
+		PatchDb, err = db.NewLDBDatabase(LdbPath+"patchDB", 0, 0)
+	})
+	return err
+}
+
+func appendLength(buf, b []byte) []byte {
+	var l [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(l[:], uint64(len(b)))
+	buf = append(buf, l[:n]...)
+	return append(buf, b...)
+}
+
+// historyItem returns the history prefix of a plain key when field is nil,
+// or of a field of the hash stored at key
+func historyItem(key, field []byte) []byte {
+	item := appendLength(append([]byte{}, historyPrefix...), key)
+	if field == nil {
+		return append(item, 'p')
+	}
+	return appendLength(append(item, 'f'), field)
+}
+
+func historyFields(key []byte) []byte {
+	return append(appendLength(append([]byte{}, historyPrefix...), key), 'f')
+}
+
+func encodeHeight(height int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	return b
+}
+
+// historyAfter returns the value item had before the first change made after
+// height, ok is false when item has not changed since then
+func historyAfter(history db.Database, item []byte, height int64) (value []byte, ok bool, err error) {
+	iter := history.Iterate(item, encodeHeight(height+1), nil)
+	defer iter.Release()
+	if !iter.Next() {
+		return nil, false, iter.Error()
+	}
+	v := iter.Value()
+	if len(v) == 0 || v[0] == 0 {
+		return nil, true, nil
+	}
+	return db.CopyBytes(v[1:]), true, nil
+}
+
+type hashGetter interface {
+	GetAll(key string) (map[string]string, error)
+}
+
+// getString returns the plain value stored at key, nil when key is absent or
+// holds a hash
+func getString(d db.Database, key []byte) ([]byte, error) {
+	if hdb, ok := d.(HashDatabase); ok {
+		t, err := hdb.Type(string(key))
+		if err != nil || t != "string" {
+			return nil, err
+		}
+		return d.Get(key)
+	}
+	ok, err := d.Has(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return d.Get(key)
+}
+
+// historyBatch wraps a batch of the state database and records the values it
+// overwrites into the history batch
+type historyBatch struct {
+	db.Batch
+	base     db.Database
+	history  db.Batch
+	hdb      db.Database
+	height   int64
+	recorded map[string]bool
+}
+
+func newHistoryBatch(batch db.Batch, base, history db.Database, height int64) *historyBatch {
+	return &historyBatch{
+		Batch:    batch,
+		base:     base,
+		history:  history.NewBatch(),
+		hdb:      history,
+		height:   height,
+		recorded: make(map[string]bool),
+	}
+}
+
+func (b *historyBatch) record(key, field []byte) error {
+	if bytes.HasPrefix(key, trieNodePrefix) {
+		return nil
+	}
+	hk := append(historyItem(key, field), encodeHeight(b.height)...)
+	if b.recorded[string(hk)] {
+		return nil
+	}
+	b.recorded[string(hk)] = true
+	// the first flush of a block wins, later ones only touch bookkeeping
+	if ok, err := b.hdb.Has(hk); err != nil || ok {
+		return err
+	}
+
+	var value []byte
+	var err error
+	if field == nil {
+		value, err = getString(b.base, key)
+		if err != nil {
+			return err
+		}
+	} else {
+		values, err := b.base.GetHM(key, field)
+		if err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			value = values[0]
+		}
+	}
+	if value == nil {
+		return b.history.Put(hk, []byte{0})
+	}
+	return b.history.Put(hk, append([]byte{1}, value...))
+}
+
+func (b *historyBatch) Put(key []byte, value []byte) error {
+	if err := b.record(key, nil); err != nil {
+		return err
+	}
+	return b.Batch.Put(key, value)
+}
+
+func (b *historyBatch) PutHM(key []byte, args ...[]byte) error {
+	for i := 0; i+1 < len(args); i += 2 {
+		if err := b.record(key, args[i]); err != nil {
+			return err
+		}
+	}
+	return b.Batch.PutHM(key, args...)
+}
+
+func (b *historyBatch) Delete(key []byte) error {
+	if err := b.record(key, nil); err != nil {
+		return err
+	}
+	return b.Batch.Delete(key)
+}
+
+func (b *historyBatch) DeleteHM(key []byte) error {
+	if hdb, ok := b.base.(hashGetter); ok {
+		fields, err := hdb.GetAll(string(key))
+		if err != nil {
+			return err
+		}
+		for f := range fields {
+			if err := b.record(key, []byte(f)); err != nil {
+				return err
+			}
+		}
+	}
+	return b.Batch.DeleteHM(key)
+}
+
+// Write commits the history before the state, so that the history never
+// misses a change of the state
+func (b *historyBatch) Write() error {
+	ok, err := b.hdb.Has(historyBeginKey)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := b.history.Put(historyBeginKey, encodeHeight(b.height)); err != nil {
+			return err
+		}
+	}
+	if err := b.history.Write(); err != nil {
+		return err
+	}
+	return b.Batch.Write()
+}
+
+// historyDatabase is a read only view of the state database as of height
+type historyDatabase struct {
+	base    db.Database
+	history db.Database
+	height  int64
+}
+
+func newHistoryDatabase(base, history db.Database, height int64) (*historyDatabase, error) {
+	ok, err := history.Has(historyBeginKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrHistoryPruned
+	}
+	begin, err := history.Get(historyBeginKey)
+	if err != nil {
+		return nil, err
+	}
+	// the state before block begin is known, except for genesis where there is none
+	if height < int64(binary.BigEndian.Uint64(begin))-1 || height < 0 {
+		return nil, ErrHistoryPruned
+	}
+	return &historyDatabase{base: base, history: history, height: height}, nil
+}
+
+func (d *historyDatabase) Get(key []byte) ([]byte, error) {
+	v, ok, err := historyAfter(d.history, historyItem(key, nil), d.height)
+	if err != nil || ok {
+		return v, err
+	}
+	return getString(d.base, key)
+}
+
+func (d *historyDatabase) GetHM(key []byte, args ...[]byte) ([][]byte, error) {
+	values := make([][]byte, 0, len(args))
+	for _, field := range args {
+		v, ok, err := historyAfter(d.history, historyItem(key, field), d.height)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			vs, err := d.base.GetHM(key, field)
+			if err != nil {
+				return nil, err
+			}
+			if len(vs) > 0 {
+				v = vs[0]
+			}
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (d *historyDatabase) Has(key []byte) (bool, error) {
+	t, err := d.Type(string(key))
+	return t != "none", err
+}
+
+// Type returns "string", "hash" or "none" like the redis TYPE command
+func (d *historyDatabase) Type(key string) (string, error) {
+	v, err := d.Get([]byte(key))
+	if err != nil {
+		return "", err
+	}
+	if v != nil {
+		return "string", nil
+	}
+	all, err := d.GetAll(key)
+	if err != nil {
+		return "", err
+	}
+	if len(all) > 0 {
+		return "hash", nil
+	}
+	return "none", nil
+}
+
+// GetAll returns all fields of the hash stored at key as of the height
+func (d *historyDatabase) GetAll(key string) (map[string]string, error) {
+	fields := make(map[string]bool)
+	if hdb, ok := d.base.(hashGetter); ok {
+		all, err := hdb.GetAll(key)
+		if err != nil {
+			return nil, err
+		}
+		for f := range all {
+			fields[f] = true
+		}
+	}
+	prefix := historyFields([]byte(key))
+	iter := d.history.Iterate(prefix, nil, nil)
+	for iter.Next() {
+		k := iter.Key()[len(prefix):]
+		l, n := binary.Uvarint(k)
+		if n <= 0 || uint64(len(k)-n) < l {
+			continue
+		}
+		fields[string(k[n:n+int(l)])] = true
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]string)
+	for f := range fields {
+		vs, err := d.GetHM([]byte(key), []byte(f))
+		if err != nil {
+			return nil, err
+		}
+		if vs[0] != nil {
+			all[f] = string(vs[0])
+		}
+	}
+	return all, nil
+}
+
+func (d *historyDatabase) Put(key []byte, value []byte) error {
+	return ErrReadOnly
+}
+
+func (d *historyDatabase) PutHM(key []byte, args ...[]byte) error {
+	return ErrReadOnly
+}
+
+func (d *historyDatabase) Delete(key []byte) error {
+	return ErrReadOnly
+}
+
+func (d *historyDatabase) DeleteHM(key []byte) error {
+	return ErrReadOnly
+}
+
+func (d *historyDatabase) Close() {
+}
+
+func (d *historyDatabase) NewBatch() db.Batch {
+	return readOnlyBatch{}
+}
+
+func (d *historyDatabase) Iterate(prefix, start, end []byte) db.Iterator {
+	return readOnlyIterator{}
+}
+
+func (d *historyDatabase) Keys(prefix []byte) ([][]byte, error) {
+	return nil, ErrReadOnly
+}
+
+type readOnlyBatch struct{}
+
+func (readOnlyBatch) Put(key []byte, value []byte) error     { return ErrReadOnly }
+func (readOnlyBatch) PutHM(key []byte, args ...[]byte) error { return ErrReadOnly }
+func (readOnlyBatch) Delete(key []byte) error                { return ErrReadOnly }
+func (readOnlyBatch) DeleteHM(key []byte) error              { return ErrReadOnly }
+func (readOnlyBatch) Write() error                           { return ErrReadOnly }
+
+type readOnlyIterator struct{}
+
+func (readOnlyIterator) Next() bool    { return false }
+func (readOnlyIterator) Key() []byte   { return nil }
+func (readOnlyIterator) Value() []byte { return nil }
+func (readOnlyIterator) Release()      {}
+func (readOnlyIterator) Error() error  { return ErrReadOnly }
@@ -0,0 +1,240 @@
+package state
+
+import (
+	"encoding/binary"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+)
+
+// PruneOptions selects what Prune removes
+type PruneOptions struct {
+	// Prefix limits the walk to the keys starting with it, a contract prefix
+	// for instance. Stale trie nodes are only collected by a walk of the
+	// whole state, with an empty Prefix.
+	Prefix string
+	// Retention is the number of blocks below the current one whose state
+	// stays readable with Pool.At, a negative Retention keeps all history
+	Retention int64
+	// DryRun only counts what would be removed
+	DryRun bool
+}
+
+// PruneReport counts what Prune removed, or would remove on a dry run
+type PruneReport struct {
+	Keys    int   // keys and hash fields still holding a deleted value
+	Nodes   int   // trie nodes no longer reachable from the state root
+	History int   // history versions older than the retention window
+	Oldest  int64 // oldest block whose state stays readable, -1 without history
+}
+
+// Prune removes from the state database d the entries that no longer hold
+// state, and from its history the versions older than opts.Retention blocks.
+// history may be nil when the history is not enabled. Neither database may
+// be written to while Prune runs.
+func Prune(d db.Database, history db.Database, opts PruneOptions) (*PruneReport, error) {
+	report := &PruneReport{Oldest: -1}
+	batch := d.NewBatch()
+
+	if err := pruneDeleted(d, batch, []byte(opts.Prefix), report); err != nil {
+		return nil, err
+	}
+	if opts.Prefix == "" {
+		if err := pruneNodes(d, batch, report); err != nil {
+			return nil, err
+		}
+	}
+
+	var hbatch db.Batch
+	if history != nil {
+		hbatch = history.NewBatch()
+		if err := pruneHistory(d, history, hbatch, opts.Retention, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	if hbatch != nil {
+		if err := hbatch.Write(); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func isDeleted(raw []byte) (bool, error) {
+	v, err := DecodeValue(raw)
+	if err != nil {
+		return false, err
+	}
+	return v == VDelete || v == VNil, nil
+}
+
+// pruneDeleted drops the values a flush left behind as VDelete, which a map
+// patch writes for every removed field
+func pruneDeleted(d db.Database, batch db.Batch, prefix []byte, report *PruneReport) error {
+	keys, err := d.Keys(prefix)
+	if err != nil {
+		return err
+	}
+	hdb, isHash := d.(HashDatabase)
+	for _, key := range keys {
+		if len(key) > 0 && key[0] == 0 {
+			continue
+		}
+		if isHash {
+			t, err := hdb.Type(string(key))
+			if err != nil {
+				return err
+			}
+			if t == "hash" {
+				if err := pruneHash(hdb, batch, key, report); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		raw, err := d.Get(key)
+		if err != nil {
+			return err
+		}
+		// a hash field stored as key+0x00+field is dropped on its own
+		if deleted, err := isDeleted(raw); err != nil || !deleted {
+			continue
+		}
+		report.Keys++
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneHash rewrites a hash without its deleted fields, since a batch can
+// only replace a hash as a whole
+func pruneHash(hdb HashDatabase, batch db.Batch, key []byte, report *PruneReport) error {
+	fields, err := hdb.GetAll(string(key))
+	if err != nil {
+		return err
+	}
+	kept := make([][]byte, 0, 2*len(fields))
+	removed := 0
+	for f, raw := range fields {
+		if deleted, err := isDeleted([]byte(raw)); err == nil && deleted {
+			removed++
+			continue
+		}
+		kept = append(kept, []byte(f), []byte(raw))
+	}
+	if removed == 0 {
+		return nil
+	}
+	report.Keys += removed
+	if err := batch.DeleteHM(key); err != nil {
+		return err
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return batch.PutHM(key, kept...)
+}
+
+// pruneNodes drops the trie nodes no flush removed, which databases written
+// before flushes deleted the nodes they replace still hold
+func pruneNodes(d db.Database, batch db.Batch, report *PruneReport) error {
+	sd := NewDatabase(d)
+	root, err := sd.root()
+	if err != nil {
+		return err
+	}
+	reachable := make(map[string]bool)
+	var walk func(hash []byte) error
+	walk = func(hash []byte) error {
+		if isEmpty(hash) || reachable[string(hash)] {
+			return nil
+		}
+		reachable[string(hash)] = true
+		node, err := sd.getNode(hash)
+		if err != nil {
+			return err
+		}
+		leaf, left, right, err := decodeNode(node)
+		if err != nil || leaf {
+			return err
+		}
+		if err := walk(left); err != nil {
+			return err
+		}
+		return walk(right)
+	}
+	if err := walk(root); err != nil {
+		return err
+	}
+
+	keys, err := d.Keys(trieNodePrefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if reachable[string(key[len(trieNodePrefix):])] {
+			continue
+		}
+		report.Nodes++
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneHistory drops the versions recorded by blocks up to the current block
+// minus retention, the state of that block can still be read afterwards
+func pruneHistory(d, history db.Database, batch db.Batch, retention int64, report *PruneReport) error {
+	ok, err := history.Has(historyBeginKey)
+	if err != nil || !ok {
+		return err
+	}
+	raw, err := history.Get(historyBeginKey)
+	if err != nil {
+		return err
+	}
+	begin := int64(binary.BigEndian.Uint64(raw))
+	report.Oldest = begin - 1
+	if report.Oldest < 0 {
+		report.Oldest = 0
+	}
+	if retention < 0 {
+		return nil
+	}
+
+	current, err := storedBlockNum(d)
+	if err != nil {
+		return err
+	}
+	keep := current - retention
+	if current < 0 || keep < begin {
+		return nil
+	}
+
+	iter := history.Iterate(historyPrefix, nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) < 8 || int64(binary.BigEndian.Uint64(key[len(key)-8:])) > keep {
+			continue
+		}
+		report.History++
+		if err := batch.Delete(db.CopyBytes(key)); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	report.Oldest = keep
+	return batch.Put(historyBeginKey, encodeHeight(keep+1))
+}
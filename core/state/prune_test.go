@@ -0,0 +1,87 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrune(t *testing.T) {
+	Convey("Test of pruning the state database", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		PatchDb, _ = db.NewMemDatabase()
+		defer func() { PatchDb = nil }()
+		pool := NewPool(NewDatabase(mdb))
+
+		pool.Put("a", MakeVInt(1))
+		pool.PutHM("iost", "x", MakeVInt(10))
+		pool.PutHM("con", "k", MakeVInt(1))
+		pool.Put("BlockNum", MakeVInt(1))
+		So(pool.Flush(), ShouldBeNil)
+
+		pool.PutHM("iost", "x", VDelete)
+		pool.PutHM("con", "k", VDelete)
+		pool.Put("a", MakeVInt(2))
+		pool.Put("BlockNum", MakeVInt(2))
+		So(pool.Flush(), ShouldBeNil)
+
+		pool.Put("a", MakeVInt(3))
+		pool.Put("BlockNum", MakeVInt(3))
+		So(pool.Flush(), ShouldBeNil)
+		root, err := pool.Root()
+		So(err, ShouldBeNil)
+		// flushes drop the nodes they replace, older databases kept them
+		mdb.Put(append(append([]byte{}, trieNodePrefix...), "stale"...), innerNode(root, root))
+
+		Convey("dry run changes nothing", func() {
+			keys, _ := mdb.Keys(nil)
+			report, err := Prune(mdb, PatchDb, PruneOptions{Retention: 1, DryRun: true})
+			So(err, ShouldBeNil)
+			So(report.Keys, ShouldEqual, 2)
+			So(report.Nodes, ShouldEqual, 1)
+			So(report.History, ShouldBeGreaterThan, 0)
+			So(report.Oldest, ShouldEqual, 2)
+			keys2, _ := mdb.Keys(nil)
+			So(len(keys2), ShouldEqual, len(keys))
+			_, err = pool.At(1)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("prefix limits the walk", func() {
+			report, err := Prune(mdb, nil, PruneOptions{Prefix: "con"})
+			So(err, ShouldBeNil)
+			So(report.Keys, ShouldEqual, 1)
+			So(report.Nodes, ShouldEqual, 0)
+			So(report.Oldest, ShouldEqual, -1)
+			report, err = Prune(mdb, nil, PruneOptions{Prefix: "con"})
+			So(err, ShouldBeNil)
+			So(report.Keys, ShouldEqual, 0)
+		})
+
+		Convey("prune keeps the state and the retained history", func() {
+			_, err := Prune(mdb, PatchDb, PruneOptions{Retention: 1})
+			So(err, ShouldBeNil)
+
+			pool2 := NewPool(NewDatabase(mdb))
+			r, err := pool2.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, root)
+			v, err := pool2.GetHM("iost", "x")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, VNil)
+
+			h2, err := pool2.At(2)
+			So(err, ShouldBeNil)
+			v, err = h2.Get("a")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i2")
+			_, err = pool2.At(1)
+			So(err, ShouldEqual, ErrHistoryPruned)
+
+			report, err := Prune(mdb, PatchDb, PruneOptions{Retention: 1})
+			So(err, ShouldBeNil)
+			So(report.Keys+report.Nodes+report.History, ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,153 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+)
+
+// Revert brings the state database d back to the state after block height
+// was committed. Every key and hash field written by a later block is reset
+// to the value recorded in history, then the history of the later blocks is
+// dropped so they can be committed again. It returns the number of keys and
+// hash fields restored. Neither database may be written to while Revert runs.
+func Revert(d, history db.Database, height int64) (int, error) {
+	if history == nil {
+		return 0, ErrHistoryDisabled
+	}
+	current, err := storedBlockNum(d)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	if height < current {
+		if _, err := newHistoryDatabase(d, history, height); err != nil {
+			return 0, err
+		}
+		restored, err = revertItems(d, history, height)
+		if err != nil {
+			return 0, err
+		}
+	}
+	// dropped last, a Revert interrupted before can be run again
+	if err := dropHistoryAfter(history, height); err != nil {
+		return 0, err
+	}
+	return restored, nil
+}
+
+func revertItems(d, history db.Database, height int64) (int, error) {
+	items := make(map[string]stateItem)
+	if err := changedItems(history, height, items); err != nil {
+		return 0, err
+	}
+	sorted := make([]stateItem, 0, len(items))
+	for _, it := range items {
+		sorted = append(sorted, it)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := bytes.Compare(sorted[i].key, sorted[j].key); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(sorted[i].field, sorted[j].field) < 0
+	})
+
+	batch := d.NewBatch()
+	for _, it := range sorted {
+		v, ok, err := historyAfter(history, historyItem(it.key, it.field), height)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		switch {
+		case it.field != nil && v == nil:
+			err = batch.PutHM(it.key, it.field, EncodeValue(VNil))
+		case it.field != nil:
+			err = batch.PutHM(it.key, it.field, v)
+		case v != nil:
+			err = batch.Put(it.key, v)
+		default:
+			// the key was absent or a hash, a hash keeps the fields restored above
+			var cur []byte
+			cur, err = getString(d, it.key)
+			if err == nil && cur != nil {
+				err = batch.Delete(it.key)
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// the trie nodes of the former root are gone once a later flush replaced
+	// them, rebuildTrie below writes them again
+	root, ok, err := historyAfter(history, historyItem(trieRootKey, nil), height)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		if root == nil {
+			err = batch.Delete(trieRootKey)
+		} else {
+			err = batch.Put(trieRootKey, root)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	if err := rebuildTrie(d, height); err != nil {
+		return 0, err
+	}
+	return len(sorted), nil
+}
+
+// rebuildTrie writes the trie of the state in d again when the nodes of its
+// root are gone
+func rebuildTrie(d db.Database, height int64) error {
+	sd := NewDatabase(d)
+	root, err := sd.root()
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(root, EmptyRoot()) {
+		return nil
+	}
+	if _, err := sd.getNode(root); err == nil {
+		return nil
+	}
+	builder := NewStateBuilder(d)
+	if err := WalkState(d, nil, height, builder.Add); err != nil {
+		return err
+	}
+	return builder.Commit()
+}
+
+// dropHistoryAfter removes the versions recorded by the blocks above height
+func dropHistoryAfter(history db.Database, height int64) error {
+	batch := history.NewBatch()
+	iter := history.Iterate(historyPrefix, nil, nil)
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) < 8 || int64(binary.BigEndian.Uint64(key[len(key)-8:])) <= height {
+			continue
+		}
+		if err := batch.Delete(db.CopyBytes(key)); err != nil {
+			iter.Release()
+			return err
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
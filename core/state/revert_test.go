@@ -0,0 +1,87 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRevert(t *testing.T) {
+	Convey("Test of reverting the state database", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		PatchDb, _ = db.NewMemDatabase()
+		defer func() { PatchDb = nil }()
+		pool := NewPool(NewDatabase(mdb))
+
+		pool.Put("a", MakeVInt(1))
+		pool.PutHM("iost", "x", MakeVInt(10))
+		pool.Put("BlockNum", MakeVInt(1))
+		So(pool.Flush(), ShouldBeNil)
+		root1, err := pool.Root()
+		So(err, ShouldBeNil)
+
+		pool.Put("a", MakeVInt(2))
+		pool.Put("b", MakeVInt(5))
+		pool.PutHM("iost", "x", VDelete)
+		pool.PutHM("iost", "y", MakeVInt(20))
+		pool.Put("BlockNum", MakeVInt(2))
+		So(pool.Flush(), ShouldBeNil)
+
+		pool.Put("a", MakeVInt(3))
+		pool.Put("BlockNum", MakeVInt(3))
+		So(pool.Flush(), ShouldBeNil)
+
+		Convey("revert restores the state and its root", func() {
+			So(pool.Revert(1), ShouldBeNil)
+
+			v, err := pool.Get("a")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i1")
+			So(pool.Has("b"), ShouldBeFalse)
+			v, err = pool.GetHM("iost", "x")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i10")
+			v, err = pool.GetHM("iost", "y")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, VNil)
+			v, err = pool.Get("BlockNum")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i1")
+
+			r, err := pool.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, root1)
+			_, err = pool.At(2)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("reverted blocks can be committed again", func() {
+			So(pool.Revert(1), ShouldBeNil)
+
+			pool.Put("a", MakeVInt(7))
+			pool.Put("BlockNum", MakeVInt(2))
+			So(pool.Flush(), ShouldBeNil)
+
+			h1, err := pool.At(1)
+			So(err, ShouldBeNil)
+			v, err := h1.Get("a")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i1")
+		})
+
+		Convey("revert rebuilds a pruned trie", func() {
+			_, err := Prune(mdb, nil, PruneOptions{})
+			So(err, ShouldBeNil)
+			So(pool.Revert(1), ShouldBeNil)
+			r, err := pool.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, root1)
+		})
+
+		Convey("revert needs the history", func() {
+			_, err := Revert(mdb, nil, 1)
+			So(err, ShouldEqual, ErrHistoryDisabled)
+		})
+	})
+}
@@ -0,0 +1,269 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
+)
+
+// storedBlockNum returns the number of the last block flushed into d, -1 when
+// d holds no block
+func storedBlockNum(d db.Database) (int64, error) {
+	raw, err := getString(d, []byte("BlockNum"))
+	if err != nil || raw == nil {
+		return -1, err
+	}
+	v, err := DecodeValue(raw)
+	if err != nil {
+		return -1, err
+	}
+	vi, ok := v.(*VInt)
+	if !ok {
+		return -1, fmt.Errorf("BlockNum is %v", v.EncodeString())
+	}
+	return int64(vi.ToInt()), nil
+}
+
+type stateItem struct {
+	key, field []byte
+}
+
+// WalkState calls fn, ordered by key and field, with every plain value, field
+// nil, and every hash field of the state in d as of block height. Values are
+// in the binary encoding. A height below the last flushed block is read from
+// history.
+func WalkState(d, history db.Database, height int64, fn func(key, field, value []byte) error) error {
+	current, err := storedBlockNum(d)
+	if err != nil {
+		return err
+	}
+	if height > current {
+		return fmt.Errorf("block %v is above the state at block %v", height, current)
+	}
+	var src db.Database = d
+	if height < current {
+		if history == nil {
+			return ErrHistoryDisabled
+		}
+		hdb, err := newHistoryDatabase(d, history, height)
+		if err != nil {
+			return err
+		}
+		src = hdb
+	}
+
+	items := make(map[string]stateItem)
+	if err := baseItems(d, items); err != nil {
+		return err
+	}
+	if height < current {
+		if err := changedItems(history, height, items); err != nil {
+			return err
+		}
+	}
+	sorted := make([]stateItem, 0, len(items))
+	for _, it := range items {
+		sorted = append(sorted, it)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := bytes.Compare(sorted[i].key, sorted[j].key); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(sorted[i].field, sorted[j].field) < 0
+	})
+
+	for _, it := range sorted {
+		var raw []byte
+		if it.field == nil {
+			if src == d {
+				raw, err = getString(d, it.key)
+			} else {
+				raw, err = src.Get(it.key)
+			}
+		} else {
+			var vs [][]byte
+			vs, err = src.GetHM(it.key, it.field)
+			if len(vs) > 0 {
+				raw = vs[0]
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		v, err := DecodeValue(raw)
+		if err != nil {
+			return err
+		}
+		if v == VNil || v == VDelete {
+			continue
+		}
+		if err := fn(it.key, it.field, EncodeValue(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addItem(items map[string]stateItem, key, field []byte) {
+	id := string(appendLength(nil, key))
+	if field != nil {
+		id += string(appendLength([]byte{'f'}, field))
+	}
+	items[id] = stateItem{key: db.CopyBytes(key), field: db.CopyBytes(field)}
+}
+
+// baseItems lists the keys and hash fields stored in d
+func baseItems(d db.Database, items map[string]stateItem) error {
+	keys, err := d.Keys(nil)
+	if err != nil {
+		return err
+	}
+	hdb, isHash := d.(HashDatabase)
+	for _, key := range keys {
+		if len(key) > 0 && key[0] == 0 {
+			continue
+		}
+		if isHash {
+			t, err := hdb.Type(string(key))
+			if err != nil {
+				return err
+			}
+			if t == "hash" {
+				fields, err := hdb.GetAll(string(key))
+				if err != nil {
+					return err
+				}
+				for f := range fields {
+					addItem(items, key, []byte(f))
+				}
+				continue
+			}
+		}
+		// a hash field stored as key+0x00+field
+		if i := bytes.IndexByte(key, 0); i > 0 {
+			addItem(items, key[:i], key[i+1:])
+			continue
+		}
+		addItem(items, key, nil)
+	}
+	return nil
+}
+
+// changedItems lists the keys and hash fields written after height, which
+// may be gone from the current state
+func changedItems(history db.Database, height int64, items map[string]stateItem) error {
+	iter := history.Iterate(historyPrefix, nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		rest := iter.Key()[len(historyPrefix):]
+		key, rest, ok := readLength(rest)
+		if !ok || len(rest) < 9 || (len(key) > 0 && key[0] == 0) {
+			continue
+		}
+		if int64(binary.BigEndian.Uint64(rest[len(rest)-8:])) <= height {
+			continue
+		}
+		switch rest[0] {
+		case 'p':
+			addItem(items, key, nil)
+		case 'f':
+			field, _, ok := readLength(rest[1 : len(rest)-8])
+			if ok {
+				addItem(items, key, field)
+			}
+		}
+	}
+	return iter.Error()
+}
+
+func readLength(b []byte) (value, rest []byte, ok bool) {
+	l, n := binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < l {
+		return nil, nil, false
+	}
+	return b[n : n+int(l)], b[n+int(l):], true
+}
+
+// StateBuilder loads plain values and hash fields into an empty state
+// database and computes the state root over them
+type StateBuilder struct {
+	batch  *Batch
+	leaves leafSet
+	nodes  map[string][]byte
+	root   []byte
+}
+
+// NewStateBuilder returns a builder writing into d, or only computing the
+// state root when d is nil
+func NewStateBuilder(d db.Database) *StateBuilder {
+	b := &StateBuilder{leaves: make(leafSet)}
+	if d != nil {
+		b.batch = &Batch{batch: d.NewBatch()}
+	}
+	return b
+}
+
+// Add adds a plain value when field is nil, or else a field of the hash at key
+func (b *StateBuilder) Add(key, field, value []byte) error {
+	v, err := DecodeValue(value)
+	if err != nil {
+		return err
+	}
+	b.root = nil
+	if field == nil {
+		if !trieExcluded(Key(key)) {
+			b.leaves.set(leafPath(Key(key)), leafHash(v))
+		}
+		if b.batch != nil {
+			return b.batch.Put(Key(key), v)
+		}
+		return nil
+	}
+	if !trieExcluded(Key(key)) {
+		b.leaves.set(fieldPath(Key(key), Key(field)), leafHash(v))
+	}
+	if b.batch != nil {
+		return b.batch.PutHM(Key(key), Key(field), v)
+	}
+	return nil
+}
+
+// Root returns the state root of the values added so far
+func (b *StateBuilder) Root() ([]byte, error) {
+	if b.root != nil {
+		return b.root, nil
+	}
+	t := newTrieUpdate(missingNode)
+	root, err := t.update(EmptyRoot(), 0, b.leaves.sorted())
+	if err != nil {
+		return nil, err
+	}
+	b.root, b.nodes = root, t.nodes
+	return root, nil
+}
+
+// Commit writes the added values together with their trie
+func (b *StateBuilder) Commit() error {
+	if b.batch == nil {
+		return ErrReadOnly
+	}
+	root, err := b.Root()
+	if err != nil {
+		return err
+	}
+	for h, node := range b.nodes {
+		if err := b.batch.putNode([]byte(h), node); err != nil {
+			return err
+		}
+	}
+	if err := b.batch.putRoot(root); err != nil {
+		return err
+	}
+	return b.batch.Write()
+}
@@ -20,18 +20,32 @@ func NewDatabase(db db.Database) Database {
 	}
 }
 
-func (d *Database) Put(key Key, value Value) error {
+type writer interface {
+	Put(key []byte, value []byte) error
+	PutHM(key []byte, args ...[]byte) error
+	Delete(key []byte) error
+}
+
+func put(w writer, key Key, value Value) error {
 	switch value.Type() {
 	case Map:
 		vi, ok := value.(*VMap)
 		if !ok {
-			d.db.Put(key.Encode(), []byte(value.EncodeString()))
+			break
 		}
 		for k, v := range vi.m {
-			d.db.PutHM(key.Encode(), k.Encode(), []byte(v.EncodeString()))
+			err := w.PutHM(key.Encode(), k.Encode(), EncodeValue(v))
+			if err != nil {
+				return err
+			}
 		}
+		return nil
 	}
-	return d.db.Put(key.Encode(), []byte(value.EncodeString()))
+	return w.Put(key.Encode(), EncodeValue(value))
+}
+
+func (d *Database) Put(key Key, value Value) error {
+	return put(d.db, key, value)
 }
 func (d *Database) Get(key Key) (Value, error) {
 	rdb, ok := d.db.(HashDatabase)
@@ -48,7 +62,7 @@ func (d *Database) Get(key Key) (Value, error) {
 			}
 			m := MakeVMap(nil)
 			for k, v := range ms {
-				val, err := ParseValue(v)
+				val, err := DecodeValue([]byte(v))
 				if err != nil {
 					return nil, err
 				}
@@ -64,14 +78,32 @@ func (d *Database) Get(key Key) (Value, error) {
 	if raw == nil {
 		return VNil, nil
 	}
-	return ParseValue(string(raw))
+	return DecodeValue(raw)
 }
 func (d *Database) Has(key Key) (bool, error) {
 	return d.db.Has(key.Encode())
 }
+
+// Delete removes key, whether it holds a plain value or a map
 func (d *Database) Delete(key Key) error {
-	return d.db.Delete(key.Encode())
+	if err := d.db.Delete(key.Encode()); err != nil {
+		return err
+	}
+	return d.db.DeleteHM(key.Encode())
 }
+
+// typeOf returns "string", "hash" or "none" like the redis TYPE command
+func (d *Database) typeOf(key Key) (string, error) {
+	if hdb, ok := d.db.(HashDatabase); ok {
+		return hdb.Type(string(key))
+	}
+	ok, err := d.db.Has(key.Encode())
+	if err != nil || !ok {
+		return "none", err
+	}
+	return "string", nil
+}
+
 func (d *Database) GetHM(key, field Key) (Value, error) {
 	raw, err := d.db.GetHM(key.Encode(), field.Encode())
 	if err != nil {
@@ -80,8 +112,75 @@ func (d *Database) GetHM(key, field Key) (Value, error) {
 	if raw == nil || raw[0] == nil {
 		return VNil, nil
 	}
-	return ParseValue(string(raw[0]))
+	return DecodeValue(raw[0])
 }
 func (d *Database) PutHM(key, field Key, value Value) error {
-	return d.db.PutHM(key.Encode(), field.Encode(), []byte(value.EncodeString()))
+	return d.db.PutHM(key.Encode(), field.Encode(), EncodeValue(value))
+}
+
+// root returns the state root stored with the last flush
+func (d *Database) root() ([]byte, error) {
+	ok, err := d.db.Has(trieRootKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return EmptyRoot(), nil
+	}
+	return d.db.Get(trieRootKey)
+}
+
+func (d *Database) getNode(hash []byte) ([]byte, error) {
+	node, err := d.db.Get(append(append([]byte{}, trieNodePrefix...), hash...))
+	if err != nil || node == nil {
+		return nil, ErrMissingTrieNode
+	}
+	return node, nil
+}
+
+func (d *Database) NewBatch() *Batch {
+	return &Batch{batch: d.db.NewBatch()}
+}
+
+// newHistoryBatch returns a batch that records the overwritten values into
+// PatchDb under height
+func (d *Database) newHistoryBatch(height int64) *Batch {
+	return &Batch{batch: newHistoryBatch(d.db.NewBatch(), d.db, PatchDb, height)}
+}
+
+// Batch collects state writes and commits them at once
+type Batch struct {
+	batch db.Batch
+}
+
+func (b *Batch) Put(key Key, value Value) error {
+	return put(b.batch, key, value)
+}
+
+func (b *Batch) PutHM(key, field Key, value Value) error {
+	return b.batch.PutHM(key.Encode(), field.Encode(), EncodeValue(value))
+}
+
+// Delete removes key, whether it holds a plain value or a map
+func (b *Batch) Delete(key Key) error {
+	if err := b.batch.Delete(key.Encode()); err != nil {
+		return err
+	}
+	return b.batch.DeleteHM(key.Encode())
+}
+
+func (b *Batch) putNode(hash, node []byte) error {
+	return b.batch.Put(append(append([]byte{}, trieNodePrefix...), hash...), node)
+}
+
+func (b *Batch) deleteNode(hash []byte) error {
+	return b.batch.Delete(append(append([]byte{}, trieNodePrefix...), hash...))
+}
+
+func (b *Batch) putRoot(root []byte) error {
+	return b.batch.Put(trieRootKey, root)
+}
+
+func (b *Batch) Write() error {
+	return b.batch.Write()
 }
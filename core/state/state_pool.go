@@ -15,6 +15,10 @@ type PoolImpl struct {
 	db     Database
 	patch  Patch
 	parent *PoolImpl
+
+	root  []byte
+	nodes map[string][]byte // trie nodes created by the patch
+	stale map[string]bool   // trie nodes replaced by the patch
 }
 
 func NewPool(db Database) Pool {
@@ -39,6 +43,7 @@ func (p *PoolImpl) GetPatch() Patch {
 
 func (p *PoolImpl) Put(key Key, value Value) {
 	p.patch.Put(key, value)
+	p.dirty()
 }
 
 func (p *PoolImpl) Get(key Key) (Value, error) {
@@ -82,39 +87,306 @@ func (p *PoolImpl) Has(key Key) bool {
 }
 func (p *PoolImpl) Delete(key Key) {
 	p.patch.Put(key, VDelete)
+	p.dirty()
+}
+
+// dirty drops the cached state root after the patch changed
+func (p *PoolImpl) dirty() {
+	p.root = nil
+	p.nodes = nil
+	p.stale = nil
+}
+
+// Root returns the root of the state trie after applying the patches of p
+// and its ancestors to the database
+func (p *PoolImpl) Root() ([]byte, error) {
+	if p.root != nil {
+		return p.root, nil
+	}
+	var base []byte
+	var err error
+	if p.parent == nil {
+		base, err = p.db.root()
+	} else {
+		base, err = p.parent.Root()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := make(leafSet)
+	for k, v := range p.patch.m {
+		if trieExcluded(k) {
+			continue
+		}
+		switch {
+		case v == VNil:
+		case v == VDelete || v.Type() != Map:
+			old, err := p.parentGet(k)
+			if err != nil {
+				return nil, err
+			}
+			removeLeaves(leaves, k, old)
+			leaves.set(leafPath(k), leafHash(v))
+		default:
+			leaves.set(leafPath(k), nil)
+			for f := range v.(*VMap).m {
+				fv, err := p.GetHM(k, f)
+				if err != nil {
+					return nil, err
+				}
+				leaves.set(fieldPath(k, f), leafHash(fv))
+			}
+		}
+	}
+
+	t := newTrieUpdate(p.readNode)
+	root, err := t.update(base, 0, leaves.sorted())
+	if err != nil {
+		return nil, err
+	}
+	p.root = root
+	p.nodes, p.stale = t.nodes, t.stale
+	return root, nil
+}
+
+func (p *PoolImpl) parentGet(key Key) (Value, error) {
+	if p.parent != nil {
+		return p.parent.Get(key)
+	}
+	v, err := p.db.Get(key)
+	if err != nil {
+		return VNil, nil
+	}
+	return v, nil
+}
+
+func (p *PoolImpl) readNode(hash []byte) ([]byte, error) {
+	if node, ok := p.memNode(hash); ok {
+		return node, nil
+	}
+	return p.db.getNode(hash)
+}
+
+// memNode returns a trie node created by p or its ancestors and not stored yet
+func (p *PoolImpl) memNode(hash []byte) ([]byte, bool) {
+	for q := p; q != nil; q = q.parent {
+		if node, ok := q.nodes[string(hash)]; ok {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// trieChanges returns the nodes of the trie at root that are not stored yet,
+// and the stored nodes the patches of p and its ancestors replaced. Nodes
+// created and replaced again before the flush are never stored.
+func (p *PoolImpl) trieChanges(root []byte) (map[string][]byte, map[string]bool, error) {
+	nodes := make(map[string][]byte)
+	var walk func(hash []byte) error
+	walk = func(hash []byte) error {
+		if isEmpty(hash) || nodes[string(hash)] != nil {
+			return nil
+		}
+		node, ok := p.memNode(hash)
+		if !ok {
+			return nil
+		}
+		nodes[string(hash)] = node
+		leaf, left, right, err := decodeNode(node)
+		if err != nil || leaf {
+			return err
+		}
+		if err := walk(left); err != nil {
+			return err
+		}
+		return walk(right)
+	}
+	if err := walk(root); err != nil {
+		return nil, nil, err
+	}
+
+	stale := make(map[string]bool)
+	for q := p; q != nil; q = q.parent {
+		for h := range q.stale {
+			if nodes[h] == nil {
+				stale[h] = true
+			}
+		}
+	}
+	return nodes, stale, nil
 }
 
 func (p *PoolImpl) Flush() error {
+	root, err := p.Root()
+	if err != nil {
+		return err
+	}
+	batch := p.db.NewBatch()
+	if height, ok := p.flushHeight(); ok && PatchDb != nil {
+		batch = p.db.newHistoryBatch(height)
+	}
+	nodes, stale, err := p.trieChanges(root)
+	if err != nil {
+		return err
+	}
+	err = p.flushTo(batch)
+	if err != nil {
+		return err
+	}
+	for h := range stale {
+		if err := batch.deleteNode([]byte(h)); err != nil {
+			return err
+		}
+	}
+	for h, node := range nodes {
+		if err := batch.putNode([]byte(h), node); err != nil {
+			return err
+		}
+	}
+	err = batch.putRoot(root)
+	if err != nil {
+		return err
+	}
+	err = batch.Write()
+	if err != nil {
+		return err
+	}
+	p.reset()
+	return nil
+}
+
+// flushHeight returns the block number committed by flushing p, if any
+func (p *PoolImpl) flushHeight() (int64, bool) {
+	for q := p; q != nil; q = q.parent {
+		if q.patch.Has("BlockNum") {
+			v, err := p.Get("BlockNum")
+			if err != nil {
+				return 0, false
+			}
+			n, ok := v.(*VInt)
+			if !ok {
+				return 0, false
+			}
+			return int64(n.ToInt()), true
+		}
+	}
+	return 0, false
+}
+
+// At returns a read only pool of the flushed state as it was after block
+// height was committed
+func (p *PoolImpl) At(height int64) (Pool, error) {
+	if PatchDb == nil {
+		return nil, ErrHistoryDisabled
+	}
+	v, err := p.db.Get("BlockNum")
+	if err == nil {
+		if n, ok := v.(*VInt); ok && height > int64(n.ToInt()) {
+			return nil, fmt.Errorf("state of block %v is not flushed yet", height)
+		}
+	}
+	hdb, err := newHistoryDatabase(p.db.db, PatchDb, height)
+	if err != nil {
+		return nil, err
+	}
+	return NewPool(NewDatabase(hdb)), nil
+}
+
+// Revert brings the flushed state back to the state after block height was
+// committed, with the values recorded in PatchDb. p must not hold unflushed
+// changes.
+func (p *PoolImpl) Revert(height int64) error {
+	if PatchDb == nil {
+		return ErrHistoryDisabled
+	}
+	if p.parent != nil || p.patch.Length() > 0 {
+		return fmt.Errorf("state pool has unflushed changes")
+	}
+	_, err := Revert(p.db.db, PatchDb, height)
+	p.dirty()
+	return err
+}
+
+// flushTo writes the patches of p and its ancestors into batch, oldest first,
+// so that later writes in the batch override earlier ones
+func (p *PoolImpl) flushTo(batch *Batch) error {
 	if p.parent != nil {
-		p.parent.Flush()
+		if err := p.parent.flushTo(batch); err != nil {
+			return err
+		}
 	}
 	for k, v := range p.patch.m {
+		var err error
 		switch {
 		case v == VDelete:
-			p.db.Delete(k)
+			err = batch.Delete(k)
+		case v == VNil:
 		case v.Type() == Map:
+			// a map replacing a plain value must not leave the value behind
+			if err = p.retype(batch, k, "string"); err != nil {
+				return err
+			}
 			vm := v.(*VMap)
 			for f, v := range vm.m {
-				v0, err := p.db.GetHM(k, f)
+				if v == VNil {
+					continue
+				}
+				err = batch.PutHM(k, f, Merge(VNil, v))
 				if err != nil {
 					return err
 				}
-				val := Merge(v0, v)
-				p.db.PutHM(k, f, val)
 			}
 		default:
-			val0, err := p.db.Get(k)
-			if err != nil {
+			// nor a plain value replacing a map the fields of the map
+			if err = p.retype(batch, k, "hash"); err != nil {
 				return err
 			}
-			val := Merge(val0, v)
+			err = batch.Put(k, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retype deletes key in batch when it has type old below p, before it is
+// written with the other type
+func (p *PoolImpl) retype(batch *Batch, key Key, old string) error {
+	t, err := p.parentType(key)
+	if err != nil || t != old {
+		return err
+	}
+	return batch.Delete(key)
+}
 
-			p.db.Put(k, val)
+// parentType returns "string", "hash" or "none", the type key has in the
+// state below p
+func (p *PoolImpl) parentType(key Key) (string, error) {
+	for q := p.parent; q != nil; q = q.parent {
+		v, ok := q.patch.m[key]
+		switch {
+		case !ok || v == VNil:
+			// unchanged by q
+		case v == VDelete:
+			return "none", nil
+		case v.Type() == Map:
+			return "hash", nil
+		default:
+			return "string", nil
 		}
 	}
+	return p.db.typeOf(key)
+}
+
+func (p *PoolImpl) reset() {
+	if p.parent != nil {
+		p.parent.reset()
+	}
 	p.patch = Patch{make(map[Key]Value)}
 	p.parent = nil
-	return nil
+	p.dirty()
 }
 
 func (p *PoolImpl) GetHM(key, field Key) (Value, error) {
@@ -153,17 +425,23 @@ func (p *PoolImpl) PutHM(key, field Key, value Value) error {
 		if m.Type() == Map {
 			m.(*VMap).Set(field, value)
 			p.patch.Put(key, m)
+			p.dirty()
 			return nil
 		}
 	}
 	m := MakeVMap(nil)
 	m.Set(field, value)
 	p.patch.Put(key, m)
+	p.dirty()
 	return nil
 }
 
 func (p *PoolImpl) MergeParent() (Pool, error) {
-	bak := *(p.parent)
+	bak := PoolImpl{
+		db:     p.parent.db,
+		patch:  p.parent.patch.copy(),
+		parent: p.parent.parent,
+	}
 	for k, v := range p.patch.m {
 		switch {
 		case v == VDelete:
@@ -198,11 +476,27 @@ var StdPool Pool
 
 var once sync.Once
 
+// DBTarget selects the backend of StdPool, one of "redis", "ldb" or "mem"
+var DBTarget = "redis"
+
+var LdbPath string
+
+// OpenDatabase opens the state database selected by DBTarget
+func OpenDatabase() (db.Database, error) {
+	if DBTarget == "ldb" {
+		return db.NewLDBDatabase(LdbPath+"stateDB", 0, 0)
+	}
+	return db.DatabaseFactory(DBTarget)
+}
+
 func PoolInstance() error {
-	bdb, err := db.DatabaseFactory("redis")
+	bdb, err := OpenDatabase()
 	if err != nil {
 		return err
 	}
+	if _, err := RebuildRoot(bdb); err != nil {
+		return err
+	}
 	mdb := NewDatabase(bdb)
 	if StdPool == nil {
 		once.Do(func() {
@@ -127,3 +127,287 @@ func TestPoolHM(t *testing.T) {
 		fmt.Println(pool.GetHM("testvmap", "hi"))
 	})
 }
+
+func TestPoolFlush(t *testing.T) {
+	Convey("test of flush on mem database", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		pool := NewPool(NewDatabase(mdb))
+		pool.Put("a", MakeVInt(1))
+		pool.Put("b", MakeVInt(2))
+		So(pool.Flush(), ShouldBeNil)
+
+		sp1 := pool.Copy()
+		sp1.PutHM("iost", "a", MakeVFloat(1))
+		sp1.Delete("a")
+		sp2 := sp1.Copy()
+		sp2.PutHM("iost", "b", MakeVFloat(2))
+		sp2.Put("b", MakeVInt(3))
+		So(sp2.Flush(), ShouldBeNil)
+
+		So(sp2.(*PoolImpl).Parent(), ShouldBeNil)
+		patch := sp1.GetPatch()
+		So(patch.Length(), ShouldEqual, 0)
+		ok, _ := mdb.Has([]byte("a"))
+		So(ok, ShouldBeFalse)
+		v, err := pool.Get("b")
+		So(err, ShouldBeNil)
+		So(v.EncodeString(), ShouldEqual, "i3")
+		v, err = pool.GetHM("iost", "b")
+		So(err, ShouldBeNil)
+		So(v.EncodeString(), ShouldEqual, MakeVFloat(2).EncodeString())
+	})
+}
+
+func TestPoolRoot(t *testing.T) {
+	Convey("test of state root", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		pool := NewPool(NewDatabase(mdb))
+		empty, err := pool.Root()
+		So(err, ShouldBeNil)
+		So(empty, ShouldResemble, EmptyRoot())
+
+		Convey("independent of write order", func() {
+			sp1 := pool.Copy()
+			sp1.Put("a", MakeVInt(1))
+			sp1.PutHM("iost", "a", MakeVFloat(1))
+			sp1.PutHM("iost", "b", MakeVFloat(2))
+
+			sp2 := pool.Copy()
+			sp2.PutHM("iost", "b", MakeVFloat(2))
+			sp3 := sp2.Copy()
+			sp3.Put("a", MakeVInt(1))
+			sp3.PutHM("iost", "a", MakeVFloat(1))
+
+			r1, err := sp1.Root()
+			So(err, ShouldBeNil)
+			r3, err := sp3.Root()
+			So(err, ShouldBeNil)
+			So(r1, ShouldResemble, r3)
+			So(r1, ShouldNotResemble, empty)
+
+			sp3.PutHM("iost", "a", MakeVFloat(3))
+			r3, err = sp3.Root()
+			So(err, ShouldBeNil)
+			So(r1, ShouldNotResemble, r3)
+		})
+
+		Convey("bookkeeping keys are not authenticated", func() {
+			sp1 := pool.Copy()
+			sp1.Put("BlockNum", MakeVInt(1))
+			r, err := sp1.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, empty)
+		})
+
+		Convey("deletion restores the previous root", func() {
+			pool.Put("a", MakeVInt(1))
+			So(pool.Flush(), ShouldBeNil)
+			r1, err := pool.Root()
+			So(err, ShouldBeNil)
+
+			sp1 := pool.Copy()
+			sp1.PutHM("iost", "a", MakeVFloat(1))
+			sp1.PutHM("iost", "b", MakeVFloat(2))
+			So(sp1.Flush(), ShouldBeNil)
+
+			sp2 := pool.Copy()
+			sp2.Delete("iost")
+			r2, err := sp2.Root()
+			So(err, ShouldBeNil)
+			So(r2, ShouldResemble, r1)
+
+			sp3 := pool.Copy()
+			sp3.Delete("a")
+			sp4 := sp3.Copy()
+			sp4.PutHM("iost", "a", VDelete)
+			sp4.PutHM("iost", "b", VDelete)
+			r4, err := sp4.Root()
+			So(err, ShouldBeNil)
+			So(r4, ShouldResemble, empty)
+		})
+
+		Convey("root survives flush", func() {
+			sp1 := pool.Copy()
+			sp1.Put("a", MakeVInt(1))
+			sp1.PutHM("iost", "a", MakeVFloat(1))
+			r1, err := sp1.Root()
+			So(err, ShouldBeNil)
+			So(sp1.Flush(), ShouldBeNil)
+
+			reopened := NewPool(NewDatabase(mdb))
+			r2, err := reopened.Root()
+			So(err, ShouldBeNil)
+			So(r2, ShouldResemble, r1)
+
+			sp2 := reopened.Copy()
+			sp2.PutHM("iost", "b", MakeVFloat(2))
+			r3, err := sp2.Root()
+			So(err, ShouldBeNil)
+
+			mdb2, _ := db.NewMemDatabase()
+			sp3 := NewPool(NewDatabase(mdb2))
+			sp3.Put("a", MakeVInt(1))
+			sp3.PutHM("iost", "a", MakeVFloat(1))
+			sp3.PutHM("iost", "b", MakeVFloat(2))
+			r4, err := sp3.Root()
+			So(err, ShouldBeNil)
+			So(r4, ShouldResemble, r3)
+		})
+
+		Convey("flush keeps only the nodes of the current root", func() {
+			sp1 := pool.Copy()
+			for i := 0; i < 100; i++ {
+				sp1.Put(Key(fmt.Sprintf("k%v", i)), MakeVInt(i))
+			}
+			So(sp1.Flush(), ShouldBeNil)
+			nodes, err := mdb.Keys(trieNodePrefix)
+			So(err, ShouldBeNil)
+			So(len(nodes), ShouldBeLessThan, 300)
+
+			sp2 := pool.Copy()
+			sp2.Put("k1", MakeVInt(-1))
+			sp3 := sp2.Copy()
+			sp3.Put("k1", MakeVInt(-2))
+			sp3.Delete("k2")
+			So(sp3.Flush(), ShouldBeNil)
+			report, err := Prune(mdb, nil, PruneOptions{DryRun: true})
+			So(err, ShouldBeNil)
+			So(report.Nodes, ShouldEqual, 0)
+			nodes2, err := mdb.Keys(trieNodePrefix)
+			So(err, ShouldBeNil)
+			So(len(nodes2), ShouldBeLessThan, len(nodes))
+
+			for i := 0; i < 100; i++ {
+				pool.Delete(Key(fmt.Sprintf("k%v", i)))
+			}
+			So(pool.Flush(), ShouldBeNil)
+			nodes, err = mdb.Keys(trieNodePrefix)
+			So(err, ShouldBeNil)
+			So(len(nodes), ShouldEqual, 0)
+			r, err := pool.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, empty)
+		})
+
+		Convey("flush replaces a map by a plain value and back", func() {
+			pool.PutHM("k", "a", MakeVInt(1))
+			So(pool.Flush(), ShouldBeNil)
+			pool.Put("k", MakeVInt(2))
+			So(pool.Flush(), ShouldBeNil)
+			v, err := NewPool(NewDatabase(mdb)).Get("k")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i2")
+			all, _ := mdb.GetAll("k")
+			So(len(all), ShouldEqual, 0)
+
+			sp1 := pool.Copy()
+			sp1.PutHM("k", "b", MakeVInt(3))
+			So(sp1.Flush(), ShouldBeNil)
+			v, err = NewPool(NewDatabase(mdb)).Get("k")
+			So(err, ShouldBeNil)
+			So(v.Type(), ShouldEqual, Map)
+			v, err = NewPool(NewDatabase(mdb)).GetHM("k", "b")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i3")
+			r, err := pool.Root()
+			So(err, ShouldBeNil)
+			r2, err := NewPool(NewDatabase(mdb)).Root()
+			So(err, ShouldBeNil)
+			So(r2, ShouldResemble, r)
+		})
+
+		Convey("merge parent leaves the parent alone", func() {
+			sp1 := pool.Copy()
+			sp1.Put("a", MakeVInt(1))
+			sp1.PutHM("iost", "a", MakeVFloat(1))
+			r1, err := sp1.Root()
+			So(err, ShouldBeNil)
+
+			sp2 := sp1.Copy()
+			sp2.Put("a", MakeVInt(2))
+			sp2.PutHM("iost", "a", MakeVFloat(2))
+			sp3, err := sp2.MergeParent()
+			So(err, ShouldBeNil)
+
+			v, err := sp1.Get("a")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, "i1")
+			v, err = sp1.GetHM("iost", "a")
+			So(err, ShouldBeNil)
+			So(v.EncodeString(), ShouldEqual, MakeVFloat(1).EncodeString())
+			r, err := sp1.Root()
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, r1)
+
+			r2, err := sp2.Root()
+			So(err, ShouldBeNil)
+			r3, err := sp3.Root()
+			So(err, ShouldBeNil)
+			So(r3, ShouldResemble, r2)
+			So(r3, ShouldNotResemble, r1)
+		})
+	})
+}
+
+func TestPoolAt(t *testing.T) {
+	Convey("test of historical state", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		PatchDb, _ = db.NewMemDatabase()
+		defer func() { PatchDb = nil }()
+		pool := NewPool(NewDatabase(mdb))
+
+		pool.Put("a", MakeVInt(1))
+		pool.PutHM("iost", "x", MakeVInt(10))
+		pool.Put("BlockNum", MakeVInt(1))
+		So(pool.Flush(), ShouldBeNil)
+		root1, err := pool.Root()
+		So(err, ShouldBeNil)
+
+		sp := pool.Copy()
+		sp.Put("a", MakeVInt(2))
+		sp.PutHM("iost", "x", MakeVInt(20))
+		sp.PutHM("iost", "y", MakeVInt(5))
+		sp.Put("BlockNum", MakeVInt(2))
+		So(sp.Flush(), ShouldBeNil)
+
+		pool.Delete("a")
+		pool.Delete("iost")
+		pool.Put("BlockNum", MakeVInt(3))
+		So(pool.Flush(), ShouldBeNil)
+
+		h1, err := pool.At(1)
+		So(err, ShouldBeNil)
+		v, err := h1.Get("a")
+		So(err, ShouldBeNil)
+		So(v.EncodeString(), ShouldEqual, "i1")
+		v, err = h1.GetHM("iost", "x")
+		So(err, ShouldBeNil)
+		So(v.EncodeString(), ShouldEqual, "i10")
+		v, err = h1.GetHM("iost", "y")
+		So(err, ShouldBeNil)
+		So(v, ShouldEqual, VNil)
+		r, err := h1.Root()
+		So(err, ShouldBeNil)
+		So(r, ShouldResemble, root1)
+
+		h2, err := pool.At(2)
+		So(err, ShouldBeNil)
+		v, err = h2.Get("a")
+		So(err, ShouldBeNil)
+		So(v.EncodeString(), ShouldEqual, "i2")
+		v, err = h2.Get("iost")
+		So(err, ShouldBeNil)
+		So(v.Type(), ShouldEqual, Map)
+		So(v.(*VMap).Get("x").EncodeString(), ShouldEqual, "i20")
+		So(v.(*VMap).Get("y").EncodeString(), ShouldEqual, "i5")
+
+		h3, err := pool.At(3)
+		So(err, ShouldBeNil)
+		So(h3.Has("a"), ShouldBeFalse)
+		So(h3.Has("iost"), ShouldBeFalse)
+
+		_, err = pool.At(4)
+		So(err, ShouldNotBeNil)
+		So(h1.Flush(), ShouldNotBeNil)
+	})
+}
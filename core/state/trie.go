@@ -0,0 +1,291 @@
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"sort"
+)
+
+// The state trie is a sparse Merkle tree over the 256 bit sha256 of the key
+// names, in which every subtree holding a single leaf is collapsed into that
+// leaf. Every plain key and every field of a hash map is a leaf; a leaf node
+// holds its path and the sha256 of the encoded value, so it hashes alike at
+// any depth. Empty subtrees hash to emptyHash and are never stored, so a
+// change only writes the nodes on the path to its leaf, about log2 of the
+// number of leaves.
+
+const (
+	leafNodeTag  byte = 0
+	innerNodeTag byte = 1
+
+	// trieNodeSize is the size of both kinds of node: a tag followed by the
+	// path and hash of a leaf, or the hashes of the children of an inner node
+	trieNodeSize = 1 + 2*sha256.Size
+)
+
+var (
+	trieNodePrefix = []byte("\x00trie")
+	trieRootKey    = []byte("\x00root")
+
+	ErrMissingTrieNode = errors.New("missing trie node")
+)
+
+var emptyHash = make([]byte, sha256.Size)
+
+// EmptyRoot returns the root of a state without any key
+func EmptyRoot() []byte {
+	return emptyHash
+}
+
+func isEmpty(hash []byte) bool {
+	return bytes.Equal(hash, emptyHash)
+}
+
+func leafNode(path, hash []byte) []byte {
+	node := make([]byte, 0, trieNodeSize)
+	node = append(node, leafNodeTag)
+	node = append(node, path...)
+	return append(node, hash...)
+}
+
+func innerNode(left, right []byte) []byte {
+	node := make([]byte, 0, trieNodeSize)
+	node = append(node, innerNodeTag)
+	node = append(node, left...)
+	return append(node, right...)
+}
+
+// decodeNode splits a stored node into the path and hash of a leaf, or the
+// left and right children of an inner node
+func decodeNode(node []byte) (leaf bool, a, b []byte, err error) {
+	if len(node) != trieNodeSize || node[0] > innerNodeTag {
+		return false, nil, nil, ErrMissingTrieNode
+	}
+	return node[0] == leafNodeTag, node[1 : 1+sha256.Size], node[1+sha256.Size:], nil
+}
+
+func hashNode(node []byte) []byte {
+	h := sha256.Sum256(node)
+	return h[:]
+}
+
+// trieExcluded reports whether key is local bookkeeping that differs between
+// nodes and must not be authenticated by the state root
+func trieExcluded(key Key) bool {
+	return key == "BlockNum" || key == "BlockHash" || (len(key) > 0 && key[0] == 0)
+}
+
+func leafPath(key Key) []byte {
+	h := sha256.Sum256([]byte(key))
+	return h[:]
+}
+
+func fieldPath(key, field Key) []byte {
+	h := sha256.New()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(field))
+	return h.Sum(nil)
+}
+
+// leafHash returns the hash stored in the leaf of v, nil when v removes the leaf
+func leafHash(v Value) []byte {
+	if v == nil || v == VNil || v == VDelete {
+		return nil
+	}
+	h := sha256.Sum256(EncodeValue(v))
+	return h[:]
+}
+
+type trieLeaf struct {
+	path []byte
+	hash []byte
+}
+
+type leafSet map[string][]byte
+
+func (s leafSet) set(path, hash []byte) {
+	s[string(path)] = hash
+}
+
+func (s leafSet) sorted() []trieLeaf {
+	leaves := make([]trieLeaf, 0, len(s))
+	for p, h := range s {
+		leaves = append(leaves, trieLeaf{[]byte(p), h})
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].path, leaves[j].path) < 0
+	})
+	return leaves
+}
+
+func bitAt(path []byte, depth int) byte {
+	return (path[depth/8] >> uint(7-depth%8)) & 1
+}
+
+// splitAt returns the index of the first of leaves, sorted by path, that goes
+// right at depth
+func splitAt(leaves []trieLeaf, depth int) int {
+	return sort.Search(len(leaves), func(i int) bool {
+		return bitAt(leaves[i].path, depth) == 1
+	})
+}
+
+// nodeReader resolves the children of a stored node
+type nodeReader func(hash []byte) ([]byte, error)
+
+// trieUpdate writes leaves into a trie, it collects the nodes it creates and
+// the hashes of the nodes it replaces
+type trieUpdate struct {
+	read  nodeReader
+	nodes map[string][]byte
+	stale map[string]bool
+}
+
+func newTrieUpdate(read nodeReader) *trieUpdate {
+	return &trieUpdate{
+		read:  read,
+		nodes: make(map[string][]byte),
+		stale: make(map[string]bool),
+	}
+}
+
+// missingNode reads the nodes of a trie that has none stored yet
+func missingNode([]byte) ([]byte, error) {
+	return nil, ErrMissingTrieNode
+}
+
+func (t *trieUpdate) node(hash []byte) ([]byte, error) {
+	if node, ok := t.nodes[string(hash)]; ok {
+		return node, nil
+	}
+	return t.read(hash)
+}
+
+func (t *trieUpdate) put(node []byte) []byte {
+	h := hashNode(node)
+	t.nodes[string(h)] = node
+	return h
+}
+
+// update writes leaves, sorted by path, into the subtree of hash at depth and
+// returns the new subtree hash. A leaf without hash removes its path.
+func (t *trieUpdate) update(hash []byte, depth int, leaves []trieLeaf) ([]byte, error) {
+	if len(leaves) == 0 {
+		return hash, nil
+	}
+	if isEmpty(hash) {
+		return t.build(depth, leaves), nil
+	}
+	node, err := t.node(hash)
+	if err != nil {
+		return nil, err
+	}
+	leaf, a, b, err := decodeNode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	var h []byte
+	if leaf {
+		// the stored leaf goes down with the new ones, unless one replaces it
+		h = t.build(depth, withLeaf(leaves, trieLeaf{a, b}))
+	} else {
+		split := splitAt(leaves, depth)
+		left, err := t.update(a, depth+1, leaves[:split])
+		if err != nil {
+			return nil, err
+		}
+		right, err := t.update(b, depth+1, leaves[split:])
+		if err != nil {
+			return nil, err
+		}
+		h, err = t.join(left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.Equal(h, hash) {
+		t.stale[string(hash)] = true
+	}
+	return h, nil
+}
+
+// build returns the hash of a subtree at depth that holds only leaves
+func (t *trieUpdate) build(depth int, leaves []trieLeaf) []byte {
+	kept := make([]trieLeaf, 0, len(leaves))
+	for _, l := range leaves {
+		if l.hash != nil {
+			kept = append(kept, l)
+		}
+	}
+	return t.subtree(depth, kept)
+}
+
+func (t *trieUpdate) subtree(depth int, leaves []trieLeaf) []byte {
+	switch len(leaves) {
+	case 0:
+		return emptyHash
+	case 1:
+		return t.put(leafNode(leaves[0].path, leaves[0].hash))
+	}
+	split := splitAt(leaves, depth)
+	left := t.subtree(depth+1, leaves[:split])
+	right := t.subtree(depth+1, leaves[split:])
+	return t.put(innerNode(left, right))
+}
+
+// join returns the hash of the subtree over left and right, a single leaf
+// below an otherwise empty subtree takes its place
+func (t *trieUpdate) join(left, right []byte) ([]byte, error) {
+	if isEmpty(left) && isEmpty(right) {
+		return emptyHash, nil
+	}
+	if isEmpty(left) || isEmpty(right) {
+		child := left
+		if isEmpty(left) {
+			child = right
+		}
+		node, err := t.node(child)
+		if err != nil {
+			return nil, err
+		}
+		leaf, _, _, err := decodeNode(node)
+		if err != nil {
+			return nil, err
+		}
+		if leaf {
+			return child, nil
+		}
+	}
+	return t.put(innerNode(left, right)), nil
+}
+
+// withLeaf returns leaves, sorted by path, with l added unless its path is
+// there already
+func withLeaf(leaves []trieLeaf, l trieLeaf) []trieLeaf {
+	i := sort.Search(len(leaves), func(i int) bool {
+		return bytes.Compare(leaves[i].path, l.path) >= 0
+	})
+	if i < len(leaves) && bytes.Equal(leaves[i].path, l.path) {
+		return leaves
+	}
+	out := make([]trieLeaf, 0, len(leaves)+1)
+	out = append(out, leaves[:i]...)
+	out = append(out, l)
+	return append(out, leaves[i:]...)
+}
+
+// removeLeaves marks every leaf of the old value v of key as removed
+func removeLeaves(s leafSet, key Key, v Value) {
+	if v == nil || v == VNil || v == VDelete {
+		return
+	}
+	s.set(leafPath(key), nil)
+	if vm, ok := v.(*VMap); ok {
+		for f := range vm.m {
+			s.set(fieldPath(key, f), nil)
+		}
+	}
+}
@@ -30,12 +30,12 @@ func (h *Holder) AddServi(tx []Tx) {
 			if err != nil {
 				continue
 			}
-			val, ok := val0.(*state.VFloat)
+			val, ok := val0.(*state.VDecimal)
 			if !ok {
 				continue
 			}
 
-			servi.SetBalance(val.ToFloat64())
+			servi.SetBalance(val.Float64())
 		}
 	}
 	h.Spool.Flush()
@@ -0,0 +1,76 @@
+package tx
+
+import (
+	"errors"
+	"time"
+)
+
+// ChainID identifies the network of this node, a tx signed for another chain id
+// is rejected so that it cannot be replayed across networks
+var ChainID int64
+
+// LegacyEndHeight is the number of the first block that takes no legacy tx, legacy
+// txs skip the nonce check and never expire so they can be replayed. No block
+// takes them by default, a chain whose blocks hold legacy txs sets it past the
+// last of them. A negative height keeps taking them.
+var LegacyEndHeight int64
+
+const (
+	// DefaultLifetime is how long a tx made by NewTx stays valid
+	DefaultLifetime = 90 * time.Second
+	// MaxLifetime bounds the expiration of a tx past its time
+	MaxLifetime = time.Hour
+)
+
+var (
+	ErrWrongChainID = errors.New("wrong chain id")
+	ErrExpiration   = errors.New("illegal expiration")
+	ErrExpired      = errors.New("tx expired")
+	ErrLegacyTx     = errors.New("legacy tx not accepted any more")
+)
+
+// Legacy reports whether the tx carries neither an expiration nor a chain id,
+// a legacy tx keeps the original encoding and hashes
+func (t *Tx) Legacy() bool {
+	return t.Expiration == 0 && t.ChainID == 0
+}
+
+// Expired reports whether the tx is no longer valid at now, in unix nanoseconds.
+// A legacy tx never expires.
+func (t *Tx) Expired(now int64) bool {
+	return !t.Legacy() && now > t.Expiration
+}
+
+// VerifyChain checks the chain id and the expiration of the tx, but not its signatures
+func (t *Tx) VerifyChain() error {
+	if t.ChainID != ChainID {
+		return ErrWrongChainID
+	}
+	if t.Legacy() {
+		return nil
+	}
+	if t.Expiration <= t.Time || t.Expiration-t.Time > int64(MaxLifetime) {
+		return ErrExpiration
+	}
+	return nil
+}
+
+// VerifyLegacyAt checks that the tx can go into block number, a legacy tx is
+// refused from LegacyEndHeight on
+func (t *Tx) VerifyLegacyAt(number int64) error {
+	if t.Legacy() && LegacyEndHeight >= 0 && number >= LegacyEndHeight {
+		return ErrLegacyTx
+	}
+	return nil
+}
+
+func (r *TxRawV0) upgrade() TxRaw {
+	return TxRaw{
+		Time:      r.Time,
+		Nonce:     r.Nonce,
+		Contract:  r.Contract,
+		Signs:     r.Signs,
+		Publisher: r.Publisher,
+		Recorder:  r.Recorder,
+	}
+}
@@ -0,0 +1,106 @@
+package tx
+
+import (
+	"testing"
+
+	"github.com/iost-official/Go-IOS-Protocol/account"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReplay(t *testing.T) {
+	Convey("Test of tx replay protection", t, func() {
+		acc, err := account.NewAccount(nil)
+		So(err, ShouldBeNil)
+
+		Convey("legacy txs keep the original layout", func() {
+			legacy := gentx()
+			legacy.Expiration = 0
+			legacy.ChainID = 0
+			So(legacy.Legacy(), ShouldBeTrue)
+			legacy, err = SignTx(legacy, acc)
+			So(err, ShouldBeNil)
+
+			var raw TxRawV0
+			n, err := raw.Unmarshal(legacy.Encode())
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, uint64(len(legacy.Encode())))
+
+			var decoded Tx
+			So(decoded.Decode(legacy.Encode()), ShouldBeNil)
+			So(decoded.Legacy(), ShouldBeTrue)
+			So(decoded.Hash(), ShouldResemble, legacy.Hash())
+			So(decoded.VerifySelf(), ShouldBeNil)
+			So(decoded.Expired(legacy.Time+int64(MaxLifetime)*2), ShouldBeFalse)
+		})
+
+		Convey("extended txs round trip", func() {
+			txx := gentx()
+			So(txx.Legacy(), ShouldBeFalse)
+			So(txx.Expiration, ShouldEqual, txx.Time+int64(DefaultLifetime))
+			txx, err = SignTx(txx, acc)
+			So(err, ShouldBeNil)
+
+			var decoded Tx
+			So(decoded.Decode(txx.Encode()), ShouldBeNil)
+			So(decoded.Expiration, ShouldEqual, txx.Expiration)
+			So(decoded.ChainID, ShouldEqual, txx.ChainID)
+			So(decoded.Hash(), ShouldResemble, txx.Hash())
+			So(decoded.VerifySelf(), ShouldBeNil)
+			So(decoded.Expired(txx.Expiration), ShouldBeFalse)
+			So(decoded.Expired(txx.Expiration+1), ShouldBeTrue)
+		})
+
+		Convey("expiration and chain id are signed", func() {
+			txx, err := SignTx(gentx(), acc)
+			So(err, ShouldBeNil)
+			txx.Expiration++
+			So(txx.VerifySelf().Error(), ShouldEqual, "publisher error")
+		})
+
+		Convey("txs of another chain are rejected", func() {
+			defer func(id int64) { ChainID = id }(ChainID)
+			txx, err := SignTx(gentx(), acc)
+			So(err, ShouldBeNil)
+			ChainID = 7
+			So(txx.VerifySelf(), ShouldEqual, ErrWrongChainID)
+
+			legacy := gentx()
+			legacy.Expiration = 0
+			legacy.ChainID = 0
+			legacy, err = SignTx(legacy, acc)
+			So(err, ShouldBeNil)
+			So(legacy.VerifySelf(), ShouldEqual, ErrWrongChainID)
+
+			txx = gentx()
+			So(txx.ChainID, ShouldEqual, 7)
+			txx, err = SignTx(txx, acc)
+			So(err, ShouldBeNil)
+			So(txx.VerifySelf(), ShouldBeNil)
+		})
+
+		Convey("legacy txs end at LegacyEndHeight", func() {
+			defer func(height int64) { LegacyEndHeight = height }(LegacyEndHeight)
+			legacy := gentx()
+			legacy.Expiration = 0
+			legacy.ChainID = 0
+			txx := gentx()
+
+			So(legacy.VerifyLegacyAt(0), ShouldEqual, ErrLegacyTx)
+			So(txx.VerifyLegacyAt(0), ShouldBeNil)
+			LegacyEndHeight = -1
+			So(legacy.VerifyLegacyAt(100), ShouldBeNil)
+			LegacyEndHeight = 10
+			So(legacy.VerifyLegacyAt(9), ShouldBeNil)
+			So(legacy.VerifyLegacyAt(10), ShouldEqual, ErrLegacyTx)
+			So(txx.VerifyLegacyAt(10), ShouldBeNil)
+		})
+
+		Convey("expiration must follow the tx time", func() {
+			txx := gentx()
+			txx.Expiration = txx.Time
+			So(txx.VerifyChain(), ShouldEqual, ErrExpiration)
+			txx.Expiration = txx.Time + int64(MaxLifetime) + 1
+			So(txx.VerifyChain(), ShouldEqual, ErrExpiration)
+		})
+	})
+}
@@ -182,6 +182,41 @@ func (sp *ServiPool) Restore() error {
 	return nil
 }
 
+// Dump flushes the pool and returns every record of the servi database
+func (sp *ServiPool) Dump() (map[string][]byte, error) {
+	sp.Flush()
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	records := make(map[string][]byte)
+	iter := ldb.Iterate(nil, nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		records[string(iter.Key())] = db.CopyBytes(iter.Value())
+	}
+	return records, iter.Error()
+}
+
+// Load writes records returned by Dump into the servi database and restores
+// the best users from them
+func (sp *ServiPool) Load(records map[string][]byte) error {
+	sp.mu.Lock()
+	batch := ldb.NewBatch()
+	for k, v := range records {
+		if err := batch.Put([]byte(k), v); err != nil {
+			sp.mu.Unlock()
+			return err
+		}
+	}
+	err := batch.Write()
+	sp.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return sp.Restore()
+}
+
 func (sp *ServiPool) userBtu(iostAccount vm.IOSTAccount) *Servi {
 
 	if servi, ok := sp.btu[iostAccount]; ok {
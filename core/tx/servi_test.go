@@ -39,7 +39,7 @@ func TestNewServi(t *testing.T) {
 
 			for k, v := range account.GenesisAccount {
 				ser, _ := s.User(vm.IOSTAccount(k))
-				ser.SetBalance(v)
+				ser.SetBalance(float64(v))
 			}
 
 			bu, _ = s.BestUser()
@@ -47,7 +47,7 @@ func TestNewServi(t *testing.T) {
 
 			for k, v := range account.GenesisAccount {
 				ser, _ := s.User(vm.IOSTAccount(k))
-				So(ser.b, ShouldEqual, v)
+				So(ser.b, ShouldEqual, float64(v))
 			}
 
 			s.Flush()
@@ -80,7 +80,7 @@ func TestInitServi(t *testing.T) {
 
 		for k, v := range account.GenesisAccount {
 			ser, _ := s.User(vm.IOSTAccount(k))
-			ser.SetBalance(v)
+			ser.SetBalance(float64(v))
 		}
 
 		s.UpdateBtu()
@@ -141,7 +141,7 @@ func TestClearHm(t *testing.T) {
 
 		for k, v := range account.GenesisAccount {
 			ser, _ := s3.User(vm.IOSTAccount(k))
-			ser.SetBalance(v)
+			ser.SetBalance(float64(v))
 		}
 
 		s3.UpdateBtu()
@@ -203,7 +203,7 @@ func TestStoreServi(t *testing.T) {
 		s.ClearBtu()
 		for k, v := range account.GenesisAccount {
 			ser, _ := s.User(vm.IOSTAccount(k))
-			ser.SetBalance(v)
+			ser.SetBalance(float64(v))
 		}
 
 		s.UpdateBtu()
@@ -212,7 +212,7 @@ func TestStoreServi(t *testing.T) {
 		s.Flush()
 		for k, v := range account.GenesisAccount {
 			ser, _ := s.User(vm.IOSTAccount(k))
-			So(ser.b, ShouldEqual, v)
+			So(ser.b, ShouldEqual, float64(v))
 		}
 
 		for k, _ := range s.btu {
@@ -227,7 +227,7 @@ func TestStoreServi(t *testing.T) {
 
 		for k, v := range account.GenesisAccount {
 			ser, _ := s.User(vm.IOSTAccount(k))
-			So(ser.b, ShouldEqual, v)
+			So(ser.b, ShouldEqual, float64(v))
 		}
 		So(len(bu), ShouldEqual, len(account.GenesisAccount))
 
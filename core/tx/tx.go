@@ -14,24 +14,29 @@ import (
 //go:generate gencode go -schema=structs.schema -package=tx
 
 type Tx struct {
-	Time      int64
-	Nonce     int64
-	Contract  vm.Contract
-	Signs     []common.Signature
-	Publisher common.Signature
-	Recorder  common.Signature
+	Time       int64
+	Nonce      int64
+	Expiration int64
+	ChainID    int64
+	Contract   vm.Contract
+	Signs      []common.Signature
+	Publisher  common.Signature
+	Recorder   common.Signature
 }
 
 func NewTx(nonce int64, contract vm.Contract) Tx {
+	now := time.Now().UnixNano()
 	return Tx{
-		Time:     time.Now().UnixNano(),
-		Nonce:    nonce,
-		Contract: contract,
+		Time:       now,
+		Nonce:      nonce,
+		Expiration: now + int64(DefaultLifetime),
+		ChainID:    ChainID,
+		Contract:   contract,
 	}
 }
 
 func SignContract(tx Tx, account account.Account) (common.Signature, error) {
-	sign, err := common.Sign(common.Secp256k1, tx.BaseHash(), account.Seckey)
+	sign, err := account.Sign(tx.BaseHash())
 	if err != nil {
 		return sign, err
 	}
@@ -40,7 +45,7 @@ func SignContract(tx Tx, account account.Account) (common.Signature, error) {
 
 func SignTx(tx Tx, account account.Account, signs ...common.Signature) (Tx, error) {
 	tx.Signs = append(tx.Signs, signs...)
-	sign, err := common.Sign(common.Secp256k1, tx.publishHash(), account.Seckey)
+	sign, err := account.Sign(tx.publishHash())
 	if err != nil {
 		return tx, err
 	}
@@ -49,7 +54,7 @@ func SignTx(tx Tx, account account.Account, signs ...common.Signature) (Tx, erro
 }
 
 func RecordTx(tx Tx, account account.Account) (Tx, error) {
-	sign, err := common.Sign(common.Secp256k1, tx.BaseHash(), account.Seckey)
+	sign, err := account.Sign(tx.BaseHash())
 	if err != nil {
 		return tx, err
 	}
@@ -61,6 +66,8 @@ func (t *Tx) String() string {
 	str := "Tx{\n"
 	str += "	Time: " + strconv.FormatInt(t.Time, 10) + ",\n"
 	str += "	Nonce: " + strconv.FormatInt(t.Nonce, 10) + ",\n"
+	str += "	Expiration: " + strconv.FormatInt(t.Expiration, 10) + ",\n"
+	str += "	ChainID: " + strconv.FormatInt(t.ChainID, 10) + ",\n"
 	str += "	Pubkey: " + string(t.Publisher.Pubkey) + ",\n"
 	str += "	Code:\n		" + t.Contract.Code() + "\n"
 	str += "}\n"
@@ -68,8 +75,15 @@ func (t *Tx) String() string {
 }
 
 func (t *Tx) BaseHash() []byte {
-	tbr := TxBaseRaw{t.Time, t.Nonce, t.Contract.Encode()}
-	b, err := tbr.Marshal(nil)
+	var b []byte
+	var err error
+	if t.Legacy() {
+		tbr := TxBaseRawV0{t.Time, t.Nonce, t.Contract.Encode()}
+		b, err = tbr.Marshal(nil)
+	} else {
+		tbr := TxBaseRaw{t.Time, t.Nonce, t.Contract.Encode(), t.Expiration, t.ChainID}
+		b, err = tbr.Marshal(nil)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -81,8 +95,15 @@ func (t *Tx) publishHash() []byte {
 	for _, sign := range t.Signs {
 		s = append(s, sign.Encode())
 	}
-	tpr := TxPublishRaw{t.Time, t.Nonce, t.Contract.Encode(), s}
-	b, err := tpr.Marshal(nil)
+	var b []byte
+	var err error
+	if t.Legacy() {
+		tpr := TxPublishRawV0{t.Time, t.Nonce, t.Contract.Encode(), s}
+		b, err = tpr.Marshal(nil)
+	} else {
+		tpr := TxPublishRaw{t.Time, t.Nonce, t.Contract.Encode(), s, t.Expiration, t.ChainID}
+		b, err = tpr.Marshal(nil)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -94,8 +115,15 @@ func (t *Tx) Encode() []byte {
 	for _, sign := range t.Signs {
 		s = append(s, sign.Encode())
 	}
-	tr := TxRaw{t.Time, t.Nonce, t.Contract.Encode(), s, t.Publisher.Encode(), []byte{}}
-	b, err := tr.Marshal(nil)
+	var b []byte
+	var err error
+	if t.Legacy() {
+		tr := TxRawV0{t.Time, t.Nonce, t.Contract.Encode(), s, t.Publisher.Encode(), []byte{}}
+		b, err = tr.Marshal(nil)
+	} else {
+		tr := TxRaw{t.Time, t.Nonce, t.Contract.Encode(), s, t.Publisher.Encode(), []byte{}, t.Expiration, t.ChainID}
+		b, err = tr.Marshal(nil)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -110,9 +138,17 @@ func (t *Tx) Decode(b []byte) (err error) {
 		}
 	}()
 
-	_, err = tr.Unmarshal(b)
-	if err != nil {
-		return err
+	// a legacy tx is a prefix of the extended layout, it is the only one that
+	// fills the whole buffer when read as legacy
+	var legacy TxRawV0
+	n, err := legacy.Unmarshal(b)
+	if err == nil && n == uint64(len(b)) {
+		tr = legacy.upgrade()
+	} else {
+		_, err = tr.Unmarshal(b)
+		if err != nil {
+			return err
+		}
 	}
 	t.Publisher.Decode(tr.Publisher)
 	for _, sr := range tr.Signs {
@@ -144,6 +180,8 @@ func (t *Tx) Decode(b []byte) (err error) {
 	}
 	t.Nonce = tr.Nonce
 	t.Time = tr.Time
+	t.Expiration = tr.Expiration
+	t.ChainID = tr.ChainID
 	t.Contract.SetPrefix(vm.HashToPrefix(t.Hash()))
 	return nil
 }
@@ -158,6 +196,9 @@ func (t *Tx) TxID() string {
 }
 
 func (t *Tx) VerifySelf() error {
+	if err := t.VerifyChain(); err != nil {
+		return err
+	}
 	baseHash := t.BaseHash()
 	for _, sign := range t.Signs {
 		ok := common.VerifySignature(baseHash, sign)
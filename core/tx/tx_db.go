@@ -1,6 +1,7 @@
 package tx
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"sync"
@@ -40,7 +41,8 @@ func TxDbInstance() TxPool {
 //Add tx to db
 func (tp *TxPoolDb) Add(tx *Tx) error {
 	hash := tx.Hash()
-	err := tp.db.Put(append(txPrefix, hash...), tx.Encode())
+	batch := tp.db.NewBatch()
+	err := batch.Put(append(txPrefix, hash...), tx.Encode())
 	if err != nil {
 		return fmt.Errorf("failed to Put hash->tx: %v", err)
 	}
@@ -49,16 +51,44 @@ func (tp *TxPoolDb) Add(tx *Tx) error {
 	NonceRaw := make([]byte, 8)
 	binary.BigEndian.PutUint64(NonceRaw, uint64(tx.Nonce))
 
-	err = tp.db.Put(append(PNPrefix, append(NonceRaw, PubKey...)...), hash)
+	err = batch.Put(append(PNPrefix, append(NonceRaw, PubKey...)...), hash)
 
 	if err != nil {
 		return fmt.Errorf("failed to Put NP->hash: %v", err)
 	}
 
+	err = batch.Write()
+	if err != nil {
+		return fmt.Errorf("failed to write tx batch: %v", err)
+	}
+
 	return nil
 }
 
+//Del removes tx and its publisher and nonce entry from db
 func (tp *TxPoolDb) Del(tx *Tx) error {
+	hash := tx.Hash()
+	batch := tp.db.NewBatch()
+	err := batch.Delete(append(txPrefix, hash...))
+	if err != nil {
+		return fmt.Errorf("failed to Delete hash->tx: %v", err)
+	}
+	NonceRaw := make([]byte, 8)
+	binary.BigEndian.PutUint64(NonceRaw, uint64(tx.Nonce))
+	PNKey := append(PNPrefix, append(NonceRaw, tx.Publisher.Pubkey...)...)
+	//the entry may already point to another tx with the same publisher and nonce
+	PNHash, err := tp.db.Get(PNKey)
+	if err == nil && bytes.Equal(PNHash, hash) {
+		err = batch.Delete(PNKey)
+		if err != nil {
+			return fmt.Errorf("failed to Delete NP->hash: %v", err)
+		}
+	}
+
+	err = batch.Write()
+	if err != nil {
+		return fmt.Errorf("failed to write tx batch: %v", err)
+	}
 	return nil
 }
 
@@ -63,6 +63,24 @@ func TestTx(t *testing.T) {
 			So(err.Error(), ShouldEqual, "signer error")
 		})
 
+		Convey("sign with other algorithms", func() {
+			for _, algo := range []common.SignAlgorithm{common.Ed25519, common.Secp256r1} {
+				signer, err := account.NewAccountWithAlgorithm(nil, algo)
+				So(err, ShouldBeNil)
+				publisher, err := account.NewAccountWithAlgorithm(nil, algo)
+				So(err, ShouldBeNil)
+
+				tx := NewTx(int64(0), mockContract)
+				sig, err := SignContract(tx, signer)
+				So(err, ShouldBeNil)
+				So(sig.Algorithm, ShouldEqual, algo)
+				tx, err = SignTx(tx, publisher, sig)
+				So(err, ShouldBeNil)
+				So(tx.VerifySelf(), ShouldBeNil)
+				So(string(vm.PubkeyToIOSTAccount(tx.Publisher.Pubkey)), ShouldEqual, publisher.ID)
+			}
+		})
+
 	})
 }
 
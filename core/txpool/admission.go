@@ -0,0 +1,143 @@
+package txpool
+
+import (
+	"errors"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/blockcache"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/log"
+	"github.com/iost-official/Go-IOS-Protocol/verifier"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// AccountQuota is the number of txs a publisher can have in the pool, it also
+	// bounds how far ahead of its last nonce a publisher can queue txs
+	AccountQuota = 64
+	// PeerQuota is the number of txs in the pool that can come from one peer, the
+	// txs published through this node are not counted
+	PeerQuota = 4096
+
+	ErrBadContract  = errors.New("contract can not be parsed")
+	ErrBalance      = errors.New("balance not enough to pay the queued txs")
+	ErrNonceAhead   = errors.New("nonce too far ahead of the last used nonce")
+	ErrAccountQuota = errors.New("publisher has too many txs in pool")
+	ErrPeerQuota    = errors.New("peer sent too many txs in pool")
+
+	rejectedTransactionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rejected_transaction_count",
+			Help: "Count of transactions refused by the tx pool, by reason",
+		},
+		[]string{"reason"},
+	)
+)
+
+// The reasons a tx is refused, the labels of rejectedTransactionCount
+const (
+	reasonDecode      = "decode"
+	reasonTimeout     = "timeout"
+	reasonVerify      = "verify"
+	reasonContract    = "contract"
+	reasonNonce       = "nonce"
+	reasonLegacy      = "legacy"
+	reasonBalance     = "balance"
+	reasonAccount     = "account_quota"
+	reasonPeer        = "peer_quota"
+	reasonKnown       = "known"
+	reasonUnderpriced = "underpriced"
+	reasonOther       = "other"
+)
+
+func init() {
+	prometheus.MustRegister(rejectedTransactionCount)
+}
+
+func rejectReason(err error) string {
+	switch err {
+	case ErrBadContract:
+		return reasonContract
+	case blockcache.ErrNonceUsed, ErrNonceAhead:
+		return reasonNonce
+	case tx.ErrLegacyTx:
+		return reasonLegacy
+	case ErrBalance:
+		return reasonBalance
+	case ErrAccountQuota:
+		return reasonAccount
+	case ErrPeerQuota:
+		return reasonPeer
+	case ErrKnownTx:
+		return reasonKnown
+	case ErrUnderpriced:
+		return reasonUnderpriced
+	}
+	return reasonOther
+}
+
+func reject(reason string) {
+	rejectedTransactionCount.WithLabelValues(reason).Inc()
+}
+
+// admit puts t, received from peer, in the pool once it passes the admission checks:
+// a legacy tx must still be taken by the next block, the contract must parse, the
+// nonce must be unused and within AccountQuota of the last used one, the publisher
+// must afford the max fee of all its queued txs in the longest chain, and the
+// publisher and the peer must be within their quotas. A tx replacing a queued one
+// with the same nonce is not held to the quotas. peer is empty for the txs published
+// through this node.
+func (pool *TxPoolServer) admit(t *tx.Tx, peer string) error {
+	if err := t.VerifyLegacyAt(int64(pool.chain.LongestChain().Length())); err != nil {
+		return err
+	}
+	if err := verifier.ParseContract(t.Contract); err != nil {
+		log.Log.D("[txpool] contract of tx %v can not be parsed: %v", t.TxID(), err)
+		return ErrBadContract
+	}
+
+	longest := pool.chain.LongestPool()
+	publisher := publisherOf(t)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	var replaced *tx.Tx
+	if !t.Legacy() {
+		last, err := blockcache.LastNonce(publisher, longest)
+		if err != nil {
+			return err
+		}
+		switch {
+		case t.Nonce <= last:
+			return blockcache.ErrNonceUsed
+		case t.Nonce > last+int64(AccountQuota):
+			return ErrNonceAhead
+		}
+		replaced = pool.listTx.Queued(publisher, t.Nonce)
+	}
+
+	if replaced == nil {
+		if pool.listTx.AccountCount(publisher) >= AccountQuota {
+			return ErrAccountQuota
+		}
+		if peer != "" && pool.listTx.PeerCount(peer) >= PeerQuota {
+			return ErrPeerQuota
+		}
+	}
+
+	fee := verifier.MaxFee(t.Contract.Info())
+	for _, queued := range pool.listTx.QueuedTxs(publisher) {
+		if queued != replaced {
+			fee = fee.Add(verifier.MaxFee(queued.Contract.Info()))
+		}
+	}
+	balance, err := verifier.BalanceOf(t.Contract.Info().Publisher, longest)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(fee) < 0 {
+		return ErrBalance
+	}
+
+	return pool.addTx(t, peer)
+}
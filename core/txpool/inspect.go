@@ -0,0 +1,107 @@
+package txpool
+
+import (
+	"github.com/iost-official/Go-IOS-Protocol/core/blockcache"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/log"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
+)
+
+// subscribeBuffer is the number of admitted txs a subscriber can fall behind by,
+// the txs admitted while its channel is full are not sent to it
+var subscribeBuffer = 128
+
+// QueueState is the state of the txs of a publisher in the pool
+type QueueState struct {
+	Last   int64   // last nonce used in the longest chain, -1 if the publisher never published a tx
+	Next   int64   // nonce of the next tx of the publisher, after the queued txs that follow Last
+	Ready  int     // queued txs with the nonces from Last+1 on, they can go into the next blocks
+	Count  int     // txs of the publisher in the pool, legacy ones included
+	Nonces []int64 // nonces of the queued txs in order
+}
+
+// PendingList returns limit txs of publisher, or of every publisher when it is empty,
+// from offset on in the order of mempool.List, and the number of txs it pages through
+func (pool *TxPoolServer) PendingList(publisher vm.IOSTAccount, offset, limit int) (tx.TransactionsList, int) {
+	pool.mu.RLock()
+	list := pool.listTx.List(publisher)
+	pool.mu.RUnlock()
+
+	total := len(list)
+	if offset < 0 || offset >= total {
+		return tx.TransactionsList{}, total
+	}
+	if limit < 0 || offset+limit > total {
+		limit = total - offset
+	}
+	return list[offset : offset+limit], total
+}
+
+// TransactionByHash returns the tx in the pool whose tx.Hash is hash, nil when there is none
+func (pool *TxPoolServer) TransactionByHash(hash []byte) *tx.Tx {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.listTx.GetByHash(hash)
+}
+
+// AccountQueue returns the state of the txs of publisher in the pool against the longest chain
+func (pool *TxPoolServer) AccountQueue(publisher vm.IOSTAccount) (QueueState, error) {
+	last, err := blockcache.LastNonce(publisher, pool.chain.LongestPool())
+	if err != nil {
+		return QueueState{}, err
+	}
+
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	qs := QueueState{
+		Last:   last,
+		Next:   last + 1,
+		Count:  pool.listTx.AccountCount(publisher),
+		Nonces: make([]int64, 0),
+	}
+	for _, t := range pool.listTx.QueuedTxs(publisher) {
+		qs.Nonces = append(qs.Nonces, t.Nonce)
+		if t.Nonce == qs.Next {
+			qs.Next++
+			qs.Ready++
+		}
+	}
+	return qs, nil
+}
+
+// SubscribeTx returns a channel receiving the txs admitted to the pool from now on,
+// the txs put back from the journal or from fork blocks are not sent
+func (pool *TxPoolServer) SubscribeTx() chan *tx.Tx {
+	pool.subMu.Lock()
+	defer pool.subMu.Unlock()
+
+	ch := make(chan *tx.Tx, subscribeBuffer)
+	pool.subs[ch] = struct{}{}
+	return ch
+}
+
+// UnsubscribeTx stops sending admitted txs to ch and closes it
+func (pool *TxPoolServer) UnsubscribeTx(ch chan *tx.Tx) {
+	pool.subMu.Lock()
+	defer pool.subMu.Unlock()
+
+	if _, ok := pool.subs[ch]; ok {
+		delete(pool.subs, ch)
+		close(ch)
+	}
+}
+
+func (pool *TxPoolServer) notifyTx(t *tx.Tx) {
+	pool.subMu.Lock()
+	defer pool.subMu.Unlock()
+
+	for ch := range pool.subs {
+		select {
+		case ch <- t:
+		default:
+			log.Log.D("[txpool] subscriber full, tx %v not sent", t.TxID())
+		}
+	}
+}
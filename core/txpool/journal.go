@@ -0,0 +1,210 @@
+package txpool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/blockcache"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/log"
+)
+
+// JournalPath is the file keeping the txs admitted to the pool across restarts,
+// an empty path turns the journal off
+var JournalPath string
+
+var ErrBadJournal = errors.New("not a tx pool journal")
+
+var journalMagic = []byte("IOSTPOOL\x01")
+
+const maxJournalRecord = 1 << 20
+
+// txJournal is the append only file of the txs admitted to the pool. Txs leaving
+// the pool are not recorded, rotate rewrites the file with the txs still there.
+type txJournal struct {
+	path   string
+	writer *os.File
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{path: path}
+}
+
+// load calls add on every tx recorded in the journal and returns the number of
+// txs read. A record cut short by a crash ends the journal, a record that does not
+// decode is skipped. Only a record length out of bounds stops the load early, the
+// records after it can not be found.
+func (j *txJournal) load(add func(t *tx.Tx)) (int, error) {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	m := make([]byte, len(journalMagic))
+	if _, err := io.ReadFull(r, m); err != nil || !bytes.Equal(m, journalMagic) {
+		return 0, ErrBadJournal
+	}
+
+	cnt := 0
+	for {
+		l, err := binary.ReadUvarint(r)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return cnt, nil
+		}
+		if err != nil || l > maxJournalRecord {
+			return cnt, ErrBadJournal
+		}
+		raw := make([]byte, l)
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return cnt, nil
+		}
+		var t tx.Tx
+		if err := t.Decode(raw); err != nil {
+			log.Log.E("[txpool] skipped broken record in journal %v: %v", j.path, err)
+			continue
+		}
+		add(&t)
+		cnt++
+	}
+}
+
+func writeRecord(w io.Writer, t *tx.Tx) error {
+	raw := t.Encode()
+	var l [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(l[:], uint64(len(raw)))
+	_, err := w.Write(append(l[:n], raw...))
+	return err
+}
+
+// insert appends t to the journal, rotate must have opened it first
+func (j *txJournal) insert(t *tx.Tx) error {
+	if j.writer == nil {
+		return errors.New("tx pool journal not open")
+	}
+	return writeRecord(j.writer, t)
+}
+
+// rotate replaces the journal with one holding txs and opens it for insert
+func (j *txJournal) rotate(txs []*tx.Tx) error {
+	if j.writer != nil {
+		j.writer.Close()
+		j.writer = nil
+	}
+
+	tmp := j.path + ".new"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	_, err = w.Write(journalMagic)
+	for _, t := range txs {
+		if err != nil {
+			break
+		}
+		err = writeRecord(w, t)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+
+	j.writer, err = os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0644)
+	return err
+}
+
+func (j *txJournal) close() error {
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
+
+// loadJournal puts the txs of the journal back in the pool, checked again against
+// the current chain like new txs, then compacts the journal to the txs admitted
+func (pool *TxPoolServer) loadJournal() {
+	if pool.journal == nil {
+		return
+	}
+	txs := make([]*tx.Tx, 0)
+	cnt, err := pool.journal.load(func(t *tx.Tx) {
+		if !pool.txTimeOut(t) {
+			txs = append(txs, t)
+		}
+	})
+	if err != nil {
+		log.Log.E("[txpool] failed to load journal %v: %v", pool.journal.path, err)
+	}
+
+	admitted := 0
+	errs := blockcache.StdSigVerifier.VerifyBatch(txs)
+	for i, t := range txs {
+		if errs[i] == nil && pool.admit(t, "") == nil {
+			admitted++
+		}
+	}
+	log.Log.I("[txpool] reloaded %v of %v txs in journal", admitted, cnt)
+
+	pool.rotateJournal()
+}
+
+// journalTx records t admitted to the pool in the journal
+func (pool *TxPoolServer) journalTx(t *tx.Tx) {
+	if pool.journal == nil {
+		return
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if err := pool.journal.insert(t); err != nil {
+		log.Log.E("[txpool] failed to journal tx %v: %v", t.TxID(), err)
+	}
+}
+
+// rotateJournal compacts the journal to the txs in the pool
+func (pool *TxPoolServer) rotateJournal() {
+	if pool.journal == nil {
+		return
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	txs := make([]*tx.Tx, 0, pool.listTx.Len())
+	pool.listTx.Range(func(hash string, t *tx.Tx) {
+		txs = append(txs, t)
+	})
+	if err := pool.journal.rotate(txs); err != nil {
+		log.Log.E("[txpool] failed to rotate journal %v: %v", pool.journal.path, err)
+	}
+}
+
+func (pool *TxPoolServer) closeJournal() {
+	if pool.journal == nil {
+		return
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.journal.close()
+}
@@ -0,0 +1,371 @@
+package txpool
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
+)
+
+// MaxPoolSize is the number of txs the pool holds at most, the cheapest txs are
+// evicted to make room for better paying ones. Zero or below leaves the pool unbounded.
+var MaxPoolSize = 50000
+
+var (
+	ErrKnownTx     = errors.New("tx already in pool")
+	ErrUnderpriced = errors.New("tx price too low to replace a tx or to enter the full pool")
+)
+
+type poolItem struct {
+	tx     *tx.Tx
+	hash   string
+	txHash string // tx.Hash of tx, the hash clients know it by
+	price  float64
+	peer   string
+	index  int // position in the eviction heap
+}
+
+// cheaper reports whether a is evicted before b, the newer of two equally priced txs goes first
+func (a *poolItem) cheaper(b *poolItem) bool {
+	if a.price != b.price {
+		return a.price < b.price
+	}
+	return a.tx.Time > b.tx.Time
+}
+
+// accountQueue holds the txs of a publisher sorted by nonce, one tx per nonce
+type accountQueue struct {
+	items []*poolItem
+}
+
+func (q *accountQueue) search(nonce int64) (int, bool) {
+	i := sort.Search(len(q.items), func(i int) bool { return q.items[i].tx.Nonce >= nonce })
+	return i, i < len(q.items) && q.items[i].tx.Nonce == nonce
+}
+
+func (q *accountQueue) get(nonce int64) *poolItem {
+	if i, ok := q.search(nonce); ok {
+		return q.items[i]
+	}
+	return nil
+}
+
+func (q *accountQueue) put(it *poolItem) {
+	i, ok := q.search(it.tx.Nonce)
+	if ok {
+		q.items[i] = it
+		return
+	}
+	q.items = append(q.items, nil)
+	copy(q.items[i+1:], q.items[i:])
+	q.items[i] = it
+}
+
+func (q *accountQueue) del(nonce int64) {
+	if i, ok := q.search(nonce); ok {
+		q.items = append(q.items[:i], q.items[i+1:]...)
+	}
+}
+
+// evictHeap is a min-heap of every tx in the pool in eviction order
+type evictHeap []*poolItem
+
+func (h evictHeap) Len() int           { return len(h) }
+func (h evictHeap) Less(i, j int) bool { return h[i].cheaper(h[j]) }
+func (h evictHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *evictHeap) Push(x interface{}) {
+	it := x.(*poolItem)
+	it.index = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *evictHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	it.index = -1
+	return it
+}
+
+// mempool keeps the txs waiting for a block. The txs of a publisher are queued by
+// nonce and a tx replaces the queued one with the same nonce only when it pays a
+// higher price. Legacy txs carry no usable nonce and are kept apart. The pool counts
+// the txs of each publisher and of each peer they came from for the quotas.
+type mempool struct {
+	items   map[string]*poolItem
+	byHash  map[string]*poolItem
+	queues  map[vm.IOSTAccount]*accountQueue
+	legacy  map[string]*poolItem
+	evict   evictHeap
+	maxSize int
+
+	accountCnt map[vm.IOSTAccount]int
+	peerCnt    map[string]int
+}
+
+func newMempool(maxSize int) *mempool {
+	return &mempool{
+		items:   make(map[string]*poolItem),
+		byHash:  make(map[string]*poolItem),
+		queues:  make(map[vm.IOSTAccount]*accountQueue),
+		legacy:  make(map[string]*poolItem),
+		evict:   make(evictHeap, 0),
+		maxSize: maxSize,
+
+		accountCnt: make(map[vm.IOSTAccount]int),
+		peerCnt:    make(map[string]int),
+	}
+}
+
+func publisherOf(t *tx.Tx) vm.IOSTAccount {
+	return vm.PubkeyToIOSTAccount(t.Publisher.Pubkey)
+}
+
+// Add puts t, received from peer, in the pool. It fails with ErrKnownTx when t is already there and with
+// ErrUnderpriced when t does not outbid the tx it would replace or, in a full pool,
+// the cheapest tx.
+func (m *mempool) Add(t *tx.Tx, peer string) error {
+	hash := t.TxID()
+	if _, ok := m.items[hash]; ok {
+		return ErrKnownTx
+	}
+	it := &poolItem{tx: t, hash: hash, txHash: string(t.Hash()), price: t.Contract.Info().Price, peer: peer}
+
+	if !t.Legacy() {
+		if queue := m.queues[publisherOf(t)]; queue != nil {
+			if old := queue.get(t.Nonce); old != nil {
+				if it.price <= old.price {
+					return ErrUnderpriced
+				}
+				m.del(old)
+			}
+		}
+	}
+
+	if m.maxSize > 0 && len(m.items) >= m.maxSize {
+		if !m.evict[0].cheaper(it) {
+			return ErrUnderpriced
+		}
+		m.del(m.evict[0])
+	}
+
+	publisher := publisherOf(t)
+	m.items[hash] = it
+	m.byHash[it.txHash] = it
+	heap.Push(&m.evict, it)
+	m.accountCnt[publisher]++
+	if peer != "" {
+		m.peerCnt[peer]++
+	}
+	if t.Legacy() {
+		m.legacy[hash] = it
+		return nil
+	}
+	queue := m.queues[publisher]
+	if queue == nil {
+		queue = &accountQueue{}
+		m.queues[publisher] = queue
+	}
+	queue.put(it)
+	return nil
+}
+
+func (m *mempool) del(it *poolItem) {
+	delete(m.items, it.hash)
+	delete(m.byHash, it.txHash)
+	if it.index >= 0 {
+		heap.Remove(&m.evict, it.index)
+	}
+	publisher := publisherOf(it.tx)
+	if m.accountCnt[publisher]--; m.accountCnt[publisher] <= 0 {
+		delete(m.accountCnt, publisher)
+	}
+	if it.peer != "" {
+		if m.peerCnt[it.peer]--; m.peerCnt[it.peer] <= 0 {
+			delete(m.peerCnt, it.peer)
+		}
+	}
+	if it.tx.Legacy() {
+		delete(m.legacy, it.hash)
+		return
+	}
+	if queue := m.queues[publisher]; queue != nil {
+		queue.del(it.tx.Nonce)
+		if len(queue.items) == 0 {
+			delete(m.queues, publisher)
+		}
+	}
+}
+
+func (m *mempool) Del(hash string) {
+	if it, ok := m.items[hash]; ok {
+		m.del(it)
+	}
+}
+
+func (m *mempool) Exist(hash string) bool {
+	_, ok := m.items[hash]
+	return ok
+}
+
+func (m *mempool) Get(hash string) *tx.Tx {
+	if it, ok := m.items[hash]; ok {
+		return it.tx
+	}
+	return nil
+}
+
+// GetByHash returns the tx whose tx.Hash is hash, nil when it is not in the pool
+func (m *mempool) GetByHash(hash []byte) *tx.Tx {
+	if it, ok := m.byHash[string(hash)]; ok {
+		return it.tx
+	}
+	return nil
+}
+
+func (m *mempool) Len() int {
+	return len(m.items)
+}
+
+// Queued returns the tx of publisher with nonce, nil when there is none
+func (m *mempool) Queued(publisher vm.IOSTAccount, nonce int64) *tx.Tx {
+	if queue := m.queues[publisher]; queue != nil {
+		if it := queue.get(nonce); it != nil {
+			return it.tx
+		}
+	}
+	return nil
+}
+
+// QueuedTxs returns the txs of publisher in nonce order, legacy txs left out
+func (m *mempool) QueuedTxs(publisher vm.IOSTAccount) tx.TransactionsList {
+	queue := m.queues[publisher]
+	if queue == nil {
+		return nil
+	}
+	list := make(tx.TransactionsList, len(queue.items))
+	for i, it := range queue.items {
+		list[i] = it.tx
+	}
+	return list
+}
+
+// AccountCount returns the number of txs of publisher in the pool
+func (m *mempool) AccountCount(publisher vm.IOSTAccount) int {
+	return m.accountCnt[publisher]
+}
+
+// PeerCount returns the number of txs in the pool received from peer
+func (m *mempool) PeerCount(peer string) int {
+	return m.peerCnt[peer]
+}
+
+// Range calls f on every tx in the pool, f must not change the pool
+func (m *mempool) Range(f func(hash string, t *tx.Tx)) {
+	for hash, it := range m.items {
+		f(hash, it.tx)
+	}
+}
+
+// List returns the txs of publisher, or of every publisher when it is empty, the
+// best paying first and the older of two equally priced txs first
+func (m *mempool) List(publisher vm.IOSTAccount) tx.TransactionsList {
+	items := make([]*poolItem, 0)
+	for _, it := range m.items {
+		if publisher == "" || publisherOf(it.tx) == publisher {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.price != b.price {
+			return a.price > b.price
+		}
+		if a.tx.Time != b.tx.Time {
+			return a.tx.Time < b.tx.Time
+		}
+		return a.hash < b.hash
+	})
+
+	list := make(tx.TransactionsList, len(items))
+	for i, it := range items {
+		list[i] = it.tx
+	}
+	return list
+}
+
+// pickCursor walks the queue of a publisher from its next nonce
+type pickCursor struct {
+	item  *poolItem
+	queue *accountQueue
+	next  int64
+}
+
+// pickHeap is a max-heap of the txs each publisher can run next, in block order
+type pickHeap []*pickCursor
+
+func (h pickHeap) Len() int { return len(h) }
+func (h pickHeap) Less(i, j int) bool {
+	a, b := h[i].item, h[j].item
+	if a.price != b.price {
+		return a.price > b.price
+	}
+	return a.tx.Time < b.tx.Time
+}
+func (h pickHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *pickHeap) Push(x interface{}) {
+	*h = append(*h, x.(*pickCursor))
+}
+
+func (h *pickHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	*h = old[:n-1]
+	return c
+}
+
+// Pick returns up to maxCnt txs ordered by price across publishers, the txs of a
+// publisher come in nonce order from next(publisher) and stop at the first missing
+// nonce. Txs for which skip reports true are left out and count as missing.
+func (m *mempool) Pick(maxCnt int, next func(vm.IOSTAccount) int64, skip func(hash string) bool) tx.TransactionsList {
+	h := make(pickHeap, 0, len(m.queues)+len(m.legacy))
+	for _, it := range m.legacy {
+		if !skip(it.hash) {
+			h = append(h, &pickCursor{item: it})
+		}
+	}
+	for publisher, queue := range m.queues {
+		c := &pickCursor{queue: queue, next: next(publisher)}
+		if c.item = queue.get(c.next); c.item != nil && !skip(c.item.hash) {
+			h = append(h, c)
+		}
+	}
+	heap.Init(&h)
+
+	list := make(tx.TransactionsList, 0)
+	for len(list) < maxCnt && h.Len() > 0 {
+		c := h[0]
+		list = append(list, c.item.tx)
+		if c.queue == nil {
+			heap.Pop(&h)
+			continue
+		}
+		c.next++
+		if c.item = c.queue.get(c.next); c.item != nil && !skip(c.item.hash) {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return list
+}
@@ -0,0 +1,45 @@
+package txpool
+
+import (
+	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/blockcache"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/log"
+)
+
+// dropForkBlocks forgets the blocks of a branch the block cache abandoned, so their
+// txs still in the pool can be picked again, and puts back in the pool the txs that
+// only came with those blocks when they pass the admission checks
+func (pool *TxPoolServer) dropForkBlocks(blks []*block.Block) {
+	pool.mu.Lock()
+	for _, blk := range blks {
+		hash := blk.HashID()
+		if pool.blockTx.Exist(hash) {
+			pool.blockTx.Del(hash)
+		}
+		pool.checkIterateBlockHash.Del(hash)
+	}
+
+	txs := make([]*tx.Tx, 0)
+	for _, blk := range blks {
+		for i := range blk.Content {
+			t := &blk.Content[i]
+			hash := t.TxID()
+			if pool.listTx.Exist(hash) || pool.txExistTxPool(hash) || pool.txTimeOut(t) {
+				continue
+			}
+			txs = append(txs, t)
+		}
+	}
+	pool.mu.Unlock()
+
+	readmitted := 0
+	errs := blockcache.StdSigVerifier.VerifyBatch(txs)
+	for i, t := range txs {
+		if errs[i] == nil && pool.admit(t, "") == nil {
+			pool.journalTx(t)
+			readmitted++
+		}
+	}
+	log.Log.I("[txpool] dropped %v fork blocks, %v of their txs back in pool", len(blks), readmitted)
+}
@@ -2,7 +2,6 @@ package txpool
 
 import (
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -13,12 +12,15 @@ import (
 	"github.com/iost-official/Go-IOS-Protocol/core/tx"
 	"github.com/iost-official/Go-IOS-Protocol/log"
 	"github.com/iost-official/Go-IOS-Protocol/network"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 var (
-	clearInterval = 11 * time.Second
-	filterTime    = 40
+	clearInterval   = 11 * time.Second
+	journalInterval = time.Minute
+	filterTime      = 40
+	intakeBatchSize = 256
 	//filterTime    = 60*60*24*7
 
 	receivedTransactionCount = prometheus.NewCounter(
@@ -36,16 +38,22 @@ func init() {
 type TxPoolServer struct {
 	chTx           chan message.Message
 	chConfirmBlock chan *block.Block
+	chReorg        chan []*block.Block
 
 	chain  blockcache.BlockCache
 	router network.Router
 
 	blockTx   blockTx
-	listTx    listTx
-	pendingTx listTx
+	listTx    *mempool
+	pendingTx tx.TransactionsList
 
 	checkIterateBlockHash blockHashList
 
+	journal *txJournal
+
+	subs  map[chan *tx.Tx]struct{}
+	subMu sync.Mutex
+
 	filterTime int64
 	mu         sync.RWMutex
 }
@@ -57,15 +65,20 @@ func NewTxPoolServer(chain blockcache.BlockCache, chConfirmBlock chan *block.Blo
 	p := &TxPoolServer{
 		chain:          chain,
 		chConfirmBlock: chConfirmBlock,
+		chReorg:        chain.OnReorgChan(),
 		blockTx: blockTx{
 			blkTx:   make(map[string]*hashMap),
 			blkTime: make(map[string]int64),
 		},
-		listTx:                listTx{list: make(map[string]*tx.Tx)},
-		pendingTx:             listTx{list: make(map[string]*tx.Tx)},
+		listTx:                newMempool(MaxPoolSize),
+		pendingTx:             make(tx.TransactionsList, 0),
 		checkIterateBlockHash: blockHashList{blockList: make(map[string]struct{}, 0)},
+		subs:                  make(map[chan *tx.Tx]struct{}),
 		filterTime:            int64(filterTime),
 	}
+	if JournalPath != "" {
+		p.journal = newTxJournal(JournalPath)
+	}
 	p.router = network.Route
 	if p.router == nil {
 		return nil, fmt.Errorf("failed to network.Route is nil")
@@ -86,6 +99,8 @@ func NewTxPoolServer(chain blockcache.BlockCache, chConfirmBlock chan *block.Blo
 
 func (pool *TxPoolServer) Start() {
 	log.Log.I("TxPoolServer Start")
+	pool.initBlockTx()
+	pool.loadJournal()
 	go pool.loop()
 }
 
@@ -93,14 +108,15 @@ func (pool *TxPoolServer) Stop() {
 	log.Log.I("TxPoolServer Stop")
 	close(pool.chTx)
 	close(pool.chConfirmBlock)
+	pool.closeJournal()
 }
 
 func (pool *TxPoolServer) loop() {
 
-	pool.initBlockTx()
-
 	clearTx := time.NewTicker(clearInterval)
 	defer clearTx.Stop()
+	rotateJournal := time.NewTicker(journalInterval)
+	defer rotateJournal.Stop()
 
 	for {
 		select {
@@ -109,18 +125,19 @@ func (pool *TxPoolServer) loop() {
 				return
 			}
 
-			var tx tx.Tx
-			err := tx.Decode(tr.Body)
-			if err != nil {
-				continue
-			}
-
-			if pool.txTimeOut(&tx) {
-				continue
-			}
-
-			if blockcache.VerifyTxSig(tx) {
-				pool.addListTx(&tx)
+			txs, peers := pool.readTxBatch(tr)
+			errs := blockcache.StdSigVerifier.VerifyBatch(txs)
+			for i, tx := range txs {
+				if errs[i] != nil {
+					reject(reasonVerify)
+					continue
+				}
+				if err := pool.admit(tx, peers[i]); err != nil {
+					reject(rejectReason(err))
+					continue
+				}
+				pool.journalTx(tx)
+				pool.notifyTx(tx)
 				receivedTransactionCount.Inc()
 			}
 
@@ -131,17 +148,61 @@ func (pool *TxPoolServer) loop() {
 			pool.addBlockTx(bl)
 			bhl := pool.blockHash(pool.chain.LongestChain())
 			pool.updateBlockHash(bhl)
+		case blks, ok := <-pool.chReorg:
+			if !ok {
+				return
+			}
+			pool.dropForkBlocks(blks)
 		case <-clearTx.C:
 			pool.delTimeOutTx()
 			pool.delTimeOutBlockTx()
+		case <-rotateJournal.C:
+			pool.rotateJournal()
+		}
+	}
+}
+
+// readTxBatch decodes first and the txs already waiting in chTx, up to
+// intakeBatchSize of them, so their signatures are verified together. It returns
+// the txs with the peers they came from. Broken and timed out txs are left out.
+func (pool *TxPoolServer) readTxBatch(first message.Message) ([]*tx.Tx, []string) {
+	txs := make([]*tx.Tx, 0, 1)
+	peers := make([]string, 0, 1)
+	add := func(msg message.Message) {
+		var t tx.Tx
+		if err := t.Decode(msg.Body); err != nil {
+			reject(reasonDecode)
+			return
+		}
+		if pool.txTimeOut(&t) {
+			reject(reasonTimeout)
+			return
 		}
+		txs = append(txs, &t)
+		peers = append(peers, msg.From)
 	}
+
+	add(first)
+	for i := 1; i < intakeBatchSize; i++ {
+		select {
+		case msg, ok := <-pool.chTx:
+			if !ok {
+				return txs, peers
+			}
+			add(msg)
+		default:
+			return txs, peers
+		}
+	}
+	return txs, peers
 }
 
 func (pool *TxPoolServer) AddTransaction(tx *message.Message) {
 	pool.chTx <- *tx
 }
 
+// PendingTransactions returns up to maxCnt txs for the next block, the best paying
+// first and the txs of each publisher in nonce order
 func (pool *TxPoolServer) PendingTransactions(maxCnt int) tx.TransactionsList {
 
 	pool.updatePending(maxCnt)
@@ -149,13 +210,8 @@ func (pool *TxPoolServer) PendingTransactions(maxCnt int) tx.TransactionsList {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
 
-	var pendingList tx.TransactionsList
-	list := pool.pendingTx.GetList()
-
-	for _, tx := range list {
-		pendingList = append(pendingList, tx)
-	}
-	sort.Sort(pendingList)
+	pendingList := make(tx.TransactionsList, len(pool.pendingTx))
+	copy(pendingList, pool.pendingTx)
 
 	return pendingList
 }
@@ -185,7 +241,7 @@ func (pool *TxPoolServer) PendingTransactionNum() int {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
 
-	return pool.pendingTx.Len()
+	return len(pool.pendingTx)
 }
 
 func (pool *TxPoolServer) BlockTxNum() int {
@@ -219,17 +275,35 @@ func (pool *TxPoolServer) slotToSec(t int64) int64 {
 	return slot.ToUnixSec()
 }
 
-func (pool *TxPoolServer) addListTx(tx *tx.Tx) {
+// addListTx puts a copy of t in the pool without the admission checks, see
+// mempool.Add for the errors
+func (pool *TxPoolServer) addListTx(t *tx.Tx) error {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
-	if !pool.listTx.Exist(tx.TxID()) {
-		pool.listTx.Add(tx)
-	}
+	return pool.addTx(t, "")
+}
 
+// addTx puts a copy of t, received from peer, in the pool. The caller holds pool.mu.
+func (pool *TxPoolServer) addTx(t *tx.Tx, peer string) error {
+	return pool.listTx.Add(&tx.Tx{
+		Time:       t.Time,
+		Nonce:      t.Nonce,
+		Expiration: t.Expiration,
+		ChainID:    t.ChainID,
+		Contract:   t.Contract,
+		Signs:      t.Signs,
+		Publisher:  t.Publisher,
+		Recorder:   t.Recorder,
+	}, peer)
 }
 
+// txTimeOut reports whether tx passed its expiration, a legacy tx times out
+// filterTime after it was made
 func (pool *TxPoolServer) txTimeOut(tx *tx.Tx) bool {
+	if !tx.Legacy() {
+		return tx.Expired(time.Now().UnixNano())
+	}
 
 	nTime := time.Now().Unix()
 	txTime := tx.Time / 1e9
@@ -244,16 +318,13 @@ func (pool *TxPoolServer) delTimeOutTx() {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
-	nTime := time.Now().Unix()
 	hashList := make([]string, 0)
 
-	list := pool.listTx.GetList()
-	for hash, tx := range list {
-		txTime := tx.Time / 1e9
-		if nTime-txTime > pool.filterTime {
+	pool.listTx.Range(func(hash string, tx *tx.Tx) {
+		if pool.txTimeOut(tx) {
 			hashList = append(hashList, hash)
 		}
-	}
+	})
 	for _, hash := range hashList {
 		pool.listTx.Del(hash)
 	}
@@ -289,21 +360,24 @@ func (pool *TxPoolServer) delTimeOutBlockTx() {
 	}
 }
 
+// updatePending picks up to maxCnt txs that can go into the next block. The txs of a
+// publisher are picked in nonce order from its next nonce in the longest chain, the
+// txs after a missing nonce are held until the gap fills.
 func (pool *TxPoolServer) updatePending(maxCnt int) {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
-	pool.pendingTx.list = make(map[string]*tx.Tx, 0)
-
-	list := pool.listTx.GetList()
-	for hash, tr := range list {
-		if !pool.txExistTxPool(hash) {
-			pool.pendingTx.Add(tr)
-			if pool.pendingTx.Len() >= maxCnt {
-				break
-			}
+	longest := pool.chain.LongestPool()
+	next := func(publisher vm.IOSTAccount) int64 {
+		last, err := blockcache.LastNonce(publisher, longest)
+		if err != nil {
+			// no tx is queued at nonce -1, the publisher is left out
+			log.Log.E("[txpool] failed to read the nonce of %v: %v", publisher, err)
+			return -1
 		}
+		return last + 1
 	}
+	pool.pendingTx = pool.listTx.Pick(maxCnt, next, pool.txExistTxPool)
 }
 
 func (pool *TxPoolServer) txExistTxPool(hash string) bool {
@@ -318,6 +392,7 @@ func (pool *TxPoolServer) txExistTxPool(hash string) bool {
 	return false
 }
 
+// blockHash returns the hashes of the blocks still in the cache of chain
 func (pool *TxPoolServer) blockHash(chain block.Chain) *blockHashList {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
@@ -429,54 +504,6 @@ func (b blockTx) Del(hash string) {
 	delete(b.blkTx, hash)
 }
 
-type listTx struct {
-	list map[string]*tx.Tx
-}
-
-func (l *listTx) GetList() map[string]*tx.Tx {
-	return l.list
-}
-
-func (l *listTx) Add(Tx *tx.Tx) {
-	if _, ok := l.list[Tx.TxID()]; ok {
-		return
-	}
-
-	l.list[Tx.TxID()] = &tx.Tx{
-		Time:      Tx.Time,
-		Nonce:     Tx.Nonce,
-		Contract:  Tx.Contract,
-		Signs:     Tx.Signs,
-		Publisher: Tx.Publisher,
-		Recorder:  Tx.Recorder,
-	}
-}
-
-func (l listTx) Len() int {
-	return len(l.list)
-}
-
-func (l listTx) Del(hash string) {
-	delete(l.list, hash)
-
-}
-
-func (l listTx) Exist(hash string) bool {
-	if _, b := l.list[hash]; b {
-		return true
-	}
-
-	return false
-}
-
-func (l listTx) Get(hash string) *tx.Tx {
-	return l.list[hash]
-}
-
-func (l listTx) Clear() {
-	l.list = make(map[string]*tx.Tx, 0)
-}
-
 type blockHashList struct {
 	blockList map[string]struct{}
 }
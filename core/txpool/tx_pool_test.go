@@ -1,7 +1,12 @@
 package txpool
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/golang/mock/gomock"
 	"github.com/iost-official/Go-IOS-Protocol/account"
@@ -62,6 +67,7 @@ func TestNewTxPoolServer(t *testing.T) {
 			panic("block.Instance error")
 		}
 
+		state.DBTarget = "mem"
 		err = state.PoolInstance()
 		if err != nil {
 			panic("state.PoolInstance error")
@@ -91,9 +97,17 @@ func TestNewTxPoolServer(t *testing.T) {
 			tx := genTx(accountList[0], 1)
 
 			tx.Time -= int64(filterTime*1e9 + 1*1e9)
+			tx.Expiration = tx.Time + 1
 			b := txPool.txTimeOut(&tx)
 			So(b, ShouldBeTrue)
 
+			legacy := genTx(accountList[0], 1)
+			legacy.Expiration = 0
+			legacy.ChainID = 0
+			So(txPool.txTimeOut(&legacy), ShouldBeFalse)
+			legacy.Time -= int64(filterTime*1e9 + 1*1e9)
+			So(txPool.txTimeOut(&legacy), ShouldBeTrue)
+
 		})
 
 		Convey("delTimeOutTx", func() {
@@ -102,6 +116,7 @@ func TestNewTxPoolServer(t *testing.T) {
 			So(txPool.TransactionNum(), ShouldEqual, 0)
 
 			tx.Time -= int64(filterTime*1e9 + 1*1e9)
+			tx.Expiration = tx.Time + 1
 			txPool.addListTx(&tx)
 			So(txPool.TransactionNum(), ShouldEqual, 1)
 
@@ -223,7 +238,7 @@ func TestNewTxPoolServer(t *testing.T) {
 
 			listTxCnt := 2
 			for i := 0; i < listTxCnt; i++ {
-				tx := genTx(accountList[0], 30+i)
+				tx := genTx(accountList[0], i)
 				txPool.addListTx(&tx)
 			}
 
@@ -235,13 +250,357 @@ func TestNewTxPoolServer(t *testing.T) {
 				txPool.addListTx(&tx)
 			}
 
-			So(txPool.TransactionNum(), ShouldEqual, len(bl[0].Content)+listTxCnt)
+			// the block txs with the nonces of the listed ones do not outbid them
+			So(txPool.TransactionNum(), ShouldEqual, len(bl[0].Content))
 			txPool.checkIterateBlockHash.Add(bl[0].HashID())
 			txPool.updatePending(500)
 
 			So(txPool.PendingTransactionNum(), ShouldEqual, listTxCnt)
 
 		})
+
+		Convey("nonce order", func() {
+			for _, nonce := range []int{2, 4, 0, 1} {
+				tx := genTx(accountList[0], nonce)
+				txPool.addListTx(&tx)
+			}
+			So(txPool.TransactionNum(), ShouldEqual, 4)
+
+			pending := txPool.PendingTransactions(100)
+			So(len(pending), ShouldEqual, 3)
+			for i, tx := range pending {
+				So(tx.Nonce, ShouldEqual, i)
+			}
+
+			tx := genTx(accountList[0], 3)
+			txPool.addListTx(&tx)
+			So(len(txPool.PendingTransactions(100)), ShouldEqual, 5)
+			So(len(txPool.PendingTransactions(2)), ShouldEqual, 2)
+		})
+
+		Convey("replace by price", func() {
+			first := genTxWithPrice(accountList[0], 0, 1)
+			So(txPool.addListTx(&first), ShouldBeNil)
+			So(txPool.addListTx(&first), ShouldEqual, ErrKnownTx)
+
+			same := genTxWithPrice(accountList[0], 0, 1)
+			So(txPool.addListTx(&same), ShouldEqual, ErrUnderpriced)
+
+			higher := genTxWithPrice(accountList[0], 0, 2)
+			So(txPool.addListTx(&higher), ShouldBeNil)
+			So(txPool.TransactionNum(), ShouldEqual, 1)
+			So(txPool.ExistTransaction(first.TxID()), ShouldBeFalse)
+
+			pending := txPool.PendingTransactions(10)
+			So(len(pending), ShouldEqual, 1)
+			So(pending[0].TxID(), ShouldEqual, higher.TxID())
+		})
+
+		Convey("admission", func() {
+			acc := accountList[0]
+			BlockCache.LongestPool().PutHM("iost", state.Key(acc.ID), state.DecimalFromInt(250))
+			signed := func(nonce int, price float64) *tx.Tx {
+				return genSignedTx(acc, nonce, price)
+			}
+
+			So(txPool.admit(signed(0, 1), ""), ShouldBeNil)
+			So(txPool.admit(signed(0, 1), ""), ShouldEqual, ErrUnderpriced)
+			So(txPool.admit(signed(1, 1), ""), ShouldBeNil)
+			So(txPool.admit(signed(2, 1), ""), ShouldEqual, ErrBalance)
+			So(txPool.admit(signed(1, 1.2), ""), ShouldBeNil)
+			So(txPool.admit(signed(1, 2), ""), ShouldEqual, ErrBalance)
+			So(txPool.admit(signed(AccountQuota, 1), ""), ShouldEqual, ErrNonceAhead)
+			So(txPool.TransactionNum(), ShouldEqual, 2)
+
+			main := lua.NewMethod(2, "main", 0, 1)
+			lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 100, Price: 1, Publisher: vm.IOSTAccount(acc.ID)}, "function main(", main)
+			broken, err := tx.SignTx(tx.NewTx(2, &lc), acc)
+			So(err, ShouldBeNil)
+			So(txPool.admit(&broken, ""), ShouldEqual, ErrBadContract)
+
+			BlockCache.LongestPool().PutHM("iost", state.Key(acc.ID), state.DecimalFromInt(1000))
+			accountQuota := AccountQuota
+			AccountQuota = 2
+			legacyEndHeight := tx.LegacyEndHeight
+			tx.LegacyEndHeight = -1
+			legacy := genTxWithPrice(acc, 0, 1)
+			legacy.Expiration, legacy.ChainID = 0, 0
+			legacy, err = tx.SignTx(legacy, acc)
+			So(err, ShouldBeNil)
+			So(txPool.admit(&legacy, ""), ShouldEqual, ErrAccountQuota)
+			So(txPool.admit(signed(0, 3), ""), ShouldBeNil)
+			AccountQuota = accountQuota
+			tx.LegacyEndHeight = legacyEndHeight
+
+			peerQuota := PeerQuota
+			PeerQuota = 1
+			So(txPool.admit(signed(2, 1), "peer"), ShouldBeNil)
+			So(txPool.admit(signed(3, 1), "peer"), ShouldEqual, ErrPeerQuota)
+			So(txPool.admit(signed(3, 1), ""), ShouldBeNil)
+			PeerQuota = peerQuota
+
+			So(rejectReason(ErrPeerQuota), ShouldEqual, reasonPeer)
+			So(rejectReason(blockcache.ErrNonceUsed), ShouldEqual, reasonNonce)
+		})
+
+		Convey("legacy tx replay", func() {
+			acc := accountList[2]
+			BlockCache.LongestPool().PutHM("iost", state.Key(acc.ID), state.DecimalFromInt(1000))
+			legacy := genTx(acc, 0)
+			legacy.Expiration, legacy.ChainID = 0, 0
+			legacy, err := tx.SignTx(legacy, acc)
+			So(err, ShouldBeNil)
+			So(txPool.admit(&legacy, ""), ShouldEqual, tx.ErrLegacyTx)
+
+			// nothing tells a legacy tx already in a block from a new one
+			defer func(height int64) { tx.LegacyEndHeight = height }(tx.LegacyEndHeight)
+			tx.LegacyEndHeight = -1
+			So(txPool.admit(&legacy, ""), ShouldBeNil)
+			txPool.listTx.Del(legacy.TxID())
+			So(txPool.admit(&legacy, ""), ShouldBeNil)
+			txPool.listTx.Del(legacy.TxID())
+
+			tx.LegacyEndHeight = int64(BlockCache.LongestChain().Length())
+			So(txPool.admit(&legacy, ""), ShouldEqual, tx.ErrLegacyTx)
+			So(txPool.admit(&legacy, ""), ShouldEqual, tx.ErrLegacyTx)
+			So(rejectReason(tx.ErrLegacyTx), ShouldEqual, reasonLegacy)
+			So(txPool.admit(genSignedTx(acc, 0, 1), ""), ShouldBeNil)
+		})
+
+		Convey("journal", func() {
+			dir, err := ioutil.TempDir("", "txpool")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			JournalPath = filepath.Join(dir, "txpool.journal")
+			defer func() { JournalPath = "" }()
+
+			acc := accountList[0]
+			BlockCache.LongestPool().PutHM("iost", state.Key(acc.ID), state.DecimalFromInt(1000))
+
+			mockRouter.EXPECT().FilteredChan(Any()).Return(txChan, nil)
+			first, err := NewTxPoolServer(BlockCache, chConfirmBlock)
+			So(err, ShouldBeNil)
+			first.loadJournal()
+			txs := []*tx.Tx{genSignedTx(acc, 0, 1), genSignedTx(acc, 1, 1), genSignedTx(acc, 1, 2)}
+			for _, t := range txs {
+				So(first.admit(t, ""), ShouldBeNil)
+				first.journalTx(t)
+			}
+			expired := genTx(acc, 2)
+			expired.Time -= int64(2 * time.Second)
+			expired.Expiration = expired.Time + int64(time.Second)
+			signedExpired, err := tx.SignTx(expired, acc)
+			So(err, ShouldBeNil)
+			So(first.addListTx(&signedExpired), ShouldBeNil)
+			first.journalTx(&signedExpired)
+			first.closeJournal()
+			So(first.TransactionNum(), ShouldEqual, 3)
+
+			// nonce 0 got into a block meanwhile
+			BlockCache.LongestPool().PutHM("nonce", state.Key(acc.ID), state.MakeVInt(0))
+			mockRouter.EXPECT().FilteredChan(Any()).Return(txChan, nil)
+			second, err := NewTxPoolServer(BlockCache, chConfirmBlock)
+			So(err, ShouldBeNil)
+			second.loadJournal()
+			second.closeJournal()
+			So(second.TransactionNum(), ShouldEqual, 1)
+			So(second.ExistTransaction(txs[2].TxID()), ShouldBeTrue)
+
+			cnt, err := newTxJournal(JournalPath).load(func(*tx.Tx) {})
+			So(err, ShouldBeNil)
+			So(cnt, ShouldEqual, 1)
+		})
+
+		Convey("journal with broken records", func() {
+			dir, err := ioutil.TempDir("", "txpool")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "txpool.journal")
+
+			acc := accountList[0]
+			txs := []*tx.Tx{genSignedTx(acc, 0, 1), genSignedTx(acc, 1, 1)}
+			j := newTxJournal(path)
+			So(j.rotate(txs[:1]), ShouldBeNil)
+			So(j.close(), ShouldBeNil)
+			good, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+
+			load := func(content []byte) ([]string, error) {
+				So(ioutil.WriteFile(path, content, 0644), ShouldBeNil)
+				loaded := make([]string, 0)
+				cnt, err := newTxJournal(path).load(func(t *tx.Tx) { loaded = append(loaded, t.TxID()) })
+				So(cnt, ShouldEqual, len(loaded))
+				return loaded, err
+			}
+
+			var second bytes.Buffer
+			So(writeRecord(&second, txs[1]), ShouldBeNil)
+
+			// a crash in the middle of the length prefix or of the record
+			loaded, err := load(append(append([]byte{}, good...), 0x80))
+			So(err, ShouldBeNil)
+			So(loaded, ShouldResemble, []string{txs[0].TxID()})
+			loaded, err = load(append(append([]byte{}, good...), second.Bytes()[:second.Len()-1]...))
+			So(err, ShouldBeNil)
+			So(loaded, ShouldResemble, []string{txs[0].TxID()})
+
+			// a record that does not decode between two good ones
+			broken := append(append([]byte{}, good...), 3, 0xff, 0xff, 0xff)
+			loaded, err = load(append(broken, second.Bytes()...))
+			So(err, ShouldBeNil)
+			So(loaded, ShouldResemble, []string{txs[0].TxID(), txs[1].TxID()})
+
+			_, err = load([]byte("not a journal"))
+			So(err, ShouldEqual, ErrBadJournal)
+		})
+
+		Convey("drop fork blocks", func() {
+			acc := accountList[1]
+			BlockCache.LongestPool().PutHM("iost", state.Key(acc.ID), state.DecimalFromInt(1000))
+			pooled, forked := genSignedTx(acc, 0, 1), genSignedTx(acc, 1, 1)
+			So(txPool.admit(pooled, ""), ShouldBeNil)
+
+			fork := &block.Block{
+				Head: block.BlockHead{
+					Number:  1,
+					Witness: witnessList[1],
+					Time:    consensus_common.GetCurrentTimestamp().Slot,
+				},
+				Content: []tx.Tx{*pooled, *forked},
+			}
+			txPool.addBlockTx(fork)
+			txPool.checkIterateBlockHash.Add(fork.HashID())
+			So(len(txPool.PendingTransactions(10)), ShouldEqual, 0)
+
+			txPool.dropForkBlocks([]*block.Block{fork})
+			So(txPool.BlockTxNum(), ShouldEqual, 0)
+			So(txPool.TransactionNum(), ShouldEqual, 2)
+			So(txPool.ExistTransaction(forked.TxID()), ShouldBeTrue)
+			So(len(txPool.PendingTransactions(10)), ShouldEqual, 2)
+
+			txPool.dropForkBlocks([]*block.Block{fork})
+			So(txPool.TransactionNum(), ShouldEqual, 2)
+		})
+
+		Convey("inspect", func() {
+			acc := accountList[2]
+			BlockCache.LongestPool().PutHM("iost", state.Key(acc.ID), state.DecimalFromInt(1000))
+			publisher := vm.IOSTAccount(acc.ID)
+			ch := txPool.SubscribeTx()
+
+			txs := []*tx.Tx{genSignedTx(acc, 0, 1), genSignedTx(acc, 1, 3), genSignedTx(acc, 3, 2)}
+			for _, t := range txs {
+				txChan <- message.Message{Body: t.Encode(), From: "peer"}
+				select {
+				case admitted := <-ch:
+					So(admitted.TxID(), ShouldEqual, t.TxID())
+				case <-time.After(5 * time.Second):
+					So("tx not sent to the subscriber", ShouldBeEmpty)
+				}
+			}
+			txPool.UnsubscribeTx(ch)
+			_, ok := <-ch
+			So(ok, ShouldBeFalse)
+
+			other := genTx(accountList[0], 0)
+			So(txPool.addListTx(&other), ShouldBeNil)
+
+			all, total := txPool.PendingList("", 0, -1)
+			So(total, ShouldEqual, 4)
+			So(all[0].TxID(), ShouldEqual, txs[1].TxID())
+			page, total := txPool.PendingList(publisher, 1, 1)
+			So(total, ShouldEqual, 3)
+			So(len(page), ShouldEqual, 1)
+			So(page[0].TxID(), ShouldEqual, txs[2].TxID())
+			page, _ = txPool.PendingList(publisher, 3, 10)
+			So(len(page), ShouldEqual, 0)
+
+			So(txPool.TransactionByHash(txs[2].Hash()).TxID(), ShouldEqual, txs[2].TxID())
+			So(txPool.TransactionByHash([]byte("nothing")), ShouldBeNil)
+
+			qs, err := txPool.AccountQueue(publisher)
+			So(err, ShouldBeNil)
+			So(qs.Last, ShouldEqual, -1)
+			So(qs.Next, ShouldEqual, 2)
+			So(qs.Ready, ShouldEqual, 2)
+			So(qs.Count, ShouldEqual, 3)
+			So(qs.Nonces, ShouldResemble, []int64{0, 1, 3})
+		})
+	})
+}
+
+func TestMempool(t *testing.T) {
+	Convey("test mempool", t, func() {
+		accs := make([]account.Account, 3)
+		for i := range accs {
+			acc, err := account.NewAccount(nil)
+			if err != nil {
+				panic("account.NewAccount error")
+			}
+			accs[i] = acc
+		}
+		signed := genSignedTx
+		first := func(vm.IOSTAccount) int64 { return 0 }
+		none := func(string) bool { return false }
+
+		Convey("pick by price across publishers in nonce order", func() {
+			m := newMempool(0)
+			So(m.Add(signed(accs[0], 0, 1), ""), ShouldBeNil)
+			So(m.Add(signed(accs[0], 1, 5), ""), ShouldBeNil)
+			So(m.Add(signed(accs[1], 0, 3), ""), ShouldBeNil)
+			So(m.Add(signed(accs[1], 2, 9), ""), ShouldBeNil)
+			So(m.Add(signed(accs[2], 0, 2), ""), ShouldBeNil)
+
+			list := m.Pick(10, first, none)
+			So(len(list), ShouldEqual, 4)
+			prices := make([]float64, 0)
+			for _, t := range list {
+				prices = append(prices, t.Contract.Info().Price)
+			}
+			So(prices, ShouldResemble, []float64{3, 2, 1, 5})
+
+			So(len(m.Pick(2, first, none)), ShouldEqual, 2)
+			skipped := m.Pick(10, first, func(hash string) bool { return m.Get(hash).Contract.Info().Price == 1 })
+			So(len(skipped), ShouldEqual, 2)
+		})
+
+		Convey("evict the cheapest when full", func() {
+			m := newMempool(3)
+			cheap := signed(accs[0], 0, 1)
+			So(m.Add(cheap, ""), ShouldBeNil)
+			So(m.Add(signed(accs[1], 0, 2), ""), ShouldBeNil)
+			So(m.Add(signed(accs[2], 0, 3), ""), ShouldBeNil)
+
+			So(m.Add(signed(accs[0], 1, 1), ""), ShouldEqual, ErrUnderpriced)
+			So(m.Len(), ShouldEqual, 3)
+
+			So(m.Add(signed(accs[1], 1, 4), ""), ShouldBeNil)
+			So(m.Len(), ShouldEqual, 3)
+			So(m.Exist(cheap.TxID()), ShouldBeFalse)
+			So(len(m.queues), ShouldEqual, 2)
+
+			So(m.Add(signed(accs[2], 0, 5), ""), ShouldBeNil)
+			So(m.Len(), ShouldEqual, 3)
+			So(len(m.evict), ShouldEqual, 3)
+		})
+
+		Convey("list and find by hash", func() {
+			m := newMempool(0)
+			cheap, dear := signed(accs[0], 0, 1), signed(accs[0], 1, 4)
+			So(m.Add(cheap, ""), ShouldBeNil)
+			So(m.Add(dear, ""), ShouldBeNil)
+			So(m.Add(signed(accs[1], 0, 2), ""), ShouldBeNil)
+
+			So(len(m.List("")), ShouldEqual, 3)
+			list := m.List(vm.IOSTAccount(accs[0].ID))
+			So(len(list), ShouldEqual, 2)
+			So(list[0].TxID(), ShouldEqual, dear.TxID())
+
+			So(m.GetByHash(cheap.Hash()).TxID(), ShouldEqual, cheap.TxID())
+			m.Del(cheap.TxID())
+			So(m.GetByHash(cheap.Hash()), ShouldBeNil)
+			So(len(m.byHash), ShouldEqual, 2)
+		})
 	})
 }
 
@@ -252,7 +611,7 @@ func BenchmarkUpdatePending(b *testing.B) {
 
 	listTxCnt := 500
 	for i := 0; i < listTxCnt; i++ {
-		tx := genTx(accountList[0], 30+i)
+		tx := genTx(accountList[0], i)
 		txPool.addListTx(&tx)
 	}
 
@@ -308,6 +667,7 @@ func envInit(b *testing.B) (blockcache.BlockCache, []account.Account, []string,
 		panic("block.Instance error")
 	}
 
+	state.DBTarget = "mem"
 	err = state.PoolInstance()
 	if err != nil {
 		panic("state.PoolInstance error")
@@ -327,18 +687,30 @@ func envInit(b *testing.B) (blockcache.BlockCache, []account.Account, []string,
 }
 
 func genTx(a account.Account, nonce int) tx.Tx {
+	return genTxWithPrice(a, nonce, 1)
+}
+
+func genTxWithPrice(a account.Account, nonce int, price float64) tx.Tx {
 	main := lua.NewMethod(2, "main", 0, 1)
 	code := `function main()
 				Put("hello", "world")
 				return "success"
 			end`
-	lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 100, Price: 1, Publisher: vm.IOSTAccount(a.ID)}, code, main)
+	lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 100, Price: price, Publisher: vm.IOSTAccount(a.ID)}, code, main)
 
 	_tx := tx.NewTx(int64(nonce), &lc)
 	//_tx, _ = tx.SignTx(_tx, a)
 	return _tx
 }
 
+func genSignedTx(a account.Account, nonce int, price float64) *tx.Tx {
+	t, err := tx.SignTx(genTxWithPrice(a, nonce, price), a)
+	if err != nil {
+		panic("tx.SignTx error")
+	}
+	return &t
+}
+
 func genBlocks(p blockcache.BlockCache, accountList []account.Account, witnessList []string, blockCnt int, txCnt int, continuity bool) (blockPool []*block.Block) {
 
 	slot := consensus_common.GetCurrentTimestamp().Slot
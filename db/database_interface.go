@@ -1,9 +1,12 @@
 package db
 
 import (
+	"bytes"
 	"errors"
 	//"io/ioutil"
 	//"os"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 //go:generate mockgen -destination mocks/mock_database.go -package db_mock github.com/iost-official/Go-IOS-Protocol/db Database
@@ -15,8 +18,20 @@ type Database interface {
 	GetHM(key []byte, args ...[]byte) ([][]byte, error)
 	Has(key []byte) (bool, error)
 	Delete(key []byte) error
+	DeleteHM(key []byte) error
 	Close()
-	//NewBatch() Batch
+	NewBatch() Batch
+	Iterate(prefix, start, end []byte) Iterator
+	Keys(prefix []byte) ([][]byte, error)
+}
+
+// Batch collects writes and commits them to the database atomically on Write
+type Batch interface {
+	Put(key []byte, value []byte) error
+	PutHM(key []byte, args ...[]byte) error
+	Delete(key []byte) error
+	DeleteHM(key []byte) error
+	Write() error
 }
 
 func DatabaseFactory(target string) (Database, error) {
@@ -34,3 +49,68 @@ func DatabaseFactory(target string) (Database, error) {
 	}
 	return nil, errors.New("target Database not found")
 }
+
+// Iterator walks key/value pairs in ascending key order, Key and Value
+// may be reused by the next call of Next, copy them to keep them around
+type Iterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Release()
+	Error() error
+}
+
+// prefixRange returns the key range [prefix+start, prefix+end) used by Iterate,
+// a nil start begins at the first key with prefix and a nil end stops after the last one
+func prefixRange(prefix, start, end []byte) *util.Range {
+	r := util.BytesPrefix(prefix)
+	if start != nil {
+		r.Start = append(CopyBytes(prefix), start...)
+	}
+	if end != nil {
+		r.Limit = append(CopyBytes(prefix), end...)
+	}
+	return r
+}
+
+func inRange(r *util.Range, key []byte) bool {
+	return bytes.Compare(key, r.Start) >= 0 && (r.Limit == nil || bytes.Compare(key, r.Limit) < 0)
+}
+
+func collectKeys(iter Iterator) ([][]byte, error) {
+	keys := make([][]byte, 0)
+	for iter.Next() {
+		keys = append(keys, CopyBytes(iter.Key()))
+	}
+	iter.Release()
+	return keys, iter.Error()
+}
+
+// hmSeparator splits a hash key from its field in backends without native hashes,
+// a field is stored as key + hmSeparator + field. Delete only removes the plain
+// value at a key, the fields of a hash go with DeleteHM.
+var hmSeparator = []byte{0x00}
+
+// errEmptyHashKey rejects hashes at the empty key, whose fields would share the
+// keys starting with hmSeparator
+var errEmptyHashKey = errors.New("empty hash key")
+
+func hmPrefix(key []byte) []byte {
+	prefix := make([]byte, 0, len(key)+len(hmSeparator))
+	prefix = append(prefix, key...)
+	return append(prefix, hmSeparator...)
+}
+
+func hmKey(key, field []byte) []byte {
+	return append(hmPrefix(key), field...)
+}
+
+func hmArgs(key []byte, args [][]byte) error {
+	if len(key) == 0 {
+		return errEmptyHashKey
+	}
+	if len(args) == 0 || len(args)%2 != 0 {
+		return errors.New("wrong number of arguments for PutHM")
+	}
+	return nil
+}
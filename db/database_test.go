@@ -1,6 +1,8 @@
 package db
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -53,3 +55,145 @@ func TestRedisDatabase_Hash(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+type hashDatabase interface {
+	Database
+	Type(key string) (string, error)
+	GetAll(key string) (map[string]string, error)
+}
+
+func TestDatabase_HashOnEmbedded(t *testing.T) {
+	Convey("Test of hash on embedded databases", t, func() {
+		dir, err := ioutil.TempDir("", "ldb_hash_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		ldb, err := NewLDBDatabase(dir, 0, 0)
+		So(err, ShouldBeNil)
+		defer ldb.Close()
+		mdb, _ := NewMemDatabase()
+
+		for _, db := range []hashDatabase{ldb, mdb} {
+			So(db.PutHM([]byte("iost"), []byte("a"), []byte("f1"), []byte("b"), []byte("f2")), ShouldBeNil)
+			So(db.PutHM([]byte("iost"), []byte("a")), ShouldNotBeNil)
+
+			rtn, err := db.GetHM([]byte("iost"), []byte("a"), []byte("c"), []byte("b"))
+			So(err, ShouldBeNil)
+			So(string(rtn[0]), ShouldEqual, "f1")
+			So(rtn[1], ShouldBeNil)
+			So(string(rtn[2]), ShouldEqual, "f2")
+
+			s, err := db.Type("iost")
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, "hash")
+			db.Put([]byte("io"), []byte("v"))
+			s, _ = db.Type("io")
+			So(s, ShouldEqual, "string")
+			s, _ = db.Type("none")
+			So(s, ShouldEqual, "none")
+
+			all, err := db.GetAll("iost")
+			So(err, ShouldBeNil)
+			So(all, ShouldResemble, map[string]string{"a": "f1", "b": "f2"})
+
+			So(db.Delete([]byte("iost")), ShouldBeNil)
+			s, _ = db.Type("iost")
+			So(s, ShouldEqual, "hash")
+			So(db.DeleteHM([]byte("iost")), ShouldBeNil)
+			s, _ = db.Type("iost")
+			So(s, ShouldEqual, "none")
+
+			db.Put([]byte("\x00root"), []byte("r"))
+			So(db.Delete([]byte{}), ShouldBeNil)
+			ok, _ := db.Has([]byte("\x00root"))
+			So(ok, ShouldBeTrue)
+			So(db.DeleteHM([]byte{}), ShouldNotBeNil)
+			So(db.PutHM([]byte{}, []byte("root"), []byte("f")), ShouldNotBeNil)
+			rtn, _ = db.GetHM([]byte{}, []byte("root"))
+			So(string(rtn[0]), ShouldEqual, "r")
+		}
+	})
+}
+
+func TestDatabase_Batch(t *testing.T) {
+	Convey("Test of batch on embedded databases", t, func() {
+		dir, err := ioutil.TempDir("", "ldb_batch_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		ldb, err := NewLDBDatabase(dir, 0, 0)
+		So(err, ShouldBeNil)
+		defer ldb.Close()
+		mdb, _ := NewMemDatabase()
+
+		for _, db := range []hashDatabase{ldb, mdb} {
+			db.Put([]byte("old"), []byte("v"))
+			batch := db.NewBatch()
+			So(batch.Put([]byte("key1"), []byte("value1")), ShouldBeNil)
+			So(batch.PutHM([]byte("iost"), []byte("a"), []byte("f1")), ShouldBeNil)
+			So(batch.Delete([]byte("old")), ShouldBeNil)
+
+			ok, _ := db.Has([]byte("key1"))
+			So(ok, ShouldBeFalse)
+			ok, _ = db.Has([]byte("old"))
+			So(ok, ShouldBeTrue)
+
+			So(batch.Write(), ShouldBeNil)
+			rtn, _ := db.Get([]byte("key1"))
+			So(string(rtn), ShouldEqual, "value1")
+			hm, _ := db.GetHM([]byte("iost"), []byte("a"))
+			So(string(hm[0]), ShouldEqual, "f1")
+			ok, _ = db.Has([]byte("old"))
+			So(ok, ShouldBeFalse)
+
+			batch = db.NewBatch()
+			So(batch.PutHM([]byte("iost"), []byte("b"), []byte("f2")), ShouldBeNil)
+			So(batch.Delete([]byte("iost")), ShouldBeNil)
+			So(batch.DeleteHM([]byte("iost")), ShouldBeNil)
+			So(batch.PutHM([]byte("iost"), []byte("c"), []byte("f3")), ShouldBeNil)
+			So(batch.Write(), ShouldBeNil)
+			hm, _ = db.GetHM([]byte("iost"), []byte("a"), []byte("b"), []byte("c"))
+			So(hm[0], ShouldBeNil)
+			So(hm[1], ShouldBeNil)
+			So(string(hm[2]), ShouldEqual, "f3")
+		}
+	})
+}
+
+func TestDatabase_Iterate(t *testing.T) {
+	Convey("Test of iterate on embedded databases", t, func() {
+		dir, err := ioutil.TempDir("", "ldb_iter_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		ldb, err := NewLDBDatabase(dir, 0, 0)
+		So(err, ShouldBeNil)
+		defer ldb.Close()
+		mdb, _ := NewMemDatabase()
+
+		for _, db := range []Database{ldb, mdb} {
+			for _, k := range []string{"n3", "n1", "n2", "H1", "t1"} {
+				db.Put([]byte(k), []byte("v"+k))
+			}
+
+			keys, err := db.Keys([]byte("n"))
+			So(err, ShouldBeNil)
+			So(keys, ShouldResemble, [][]byte{[]byte("n1"), []byte("n2"), []byte("n3")})
+
+			iter := db.Iterate([]byte("n"), []byte("2"), nil)
+			So(iter.Next(), ShouldBeTrue)
+			So(string(iter.Key()), ShouldEqual, "n2")
+			So(string(iter.Value()), ShouldEqual, "vn2")
+			So(iter.Next(), ShouldBeTrue)
+			So(string(iter.Key()), ShouldEqual, "n3")
+			So(iter.Next(), ShouldBeFalse)
+			iter.Release()
+			So(iter.Error(), ShouldBeNil)
+
+			keys, err = collectKeys(db.Iterate([]byte("n"), []byte("1"), []byte("3")))
+			So(err, ShouldBeNil)
+			So(len(keys), ShouldEqual, 2)
+
+			keys, err = db.Keys(nil)
+			So(err, ShouldBeNil)
+			So(len(keys), ShouldEqual, 5)
+		}
+	})
+}
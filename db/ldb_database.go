@@ -8,6 +8,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 type LDBDatabase struct {
@@ -65,7 +66,11 @@ func (db *LDBDatabase) Put(key []byte, value []byte) error {
 }
 
 func (db *LDBDatabase) PutHM(key []byte, args ...[]byte) error {
-	return errors.New("Unsupported")
+	batch := db.NewBatch()
+	if err := batch.PutHM(key, args...); err != nil {
+		return err
+	}
+	return batch.Write()
 }
 
 func (db *LDBDatabase) Get(key []byte) ([]byte, error) {
@@ -77,7 +82,19 @@ func (db *LDBDatabase) Get(key []byte) ([]byte, error) {
 }
 
 func (db *LDBDatabase) GetHM(key []byte, args ...[]byte) ([][]byte, error) {
-	return nil, errors.New("Unsupported")
+	values := make([][]byte, 0, len(args))
+	for _, field := range args {
+		value, err := db.db.Get(hmKey(key, field), nil)
+		if err == leveldb.ErrNotFound {
+			values = append(values, nil)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
 }
 
 func (db *LDBDatabase) Has(key []byte) (bool, error) {
@@ -88,10 +105,65 @@ func (db *LDBDatabase) Delete(key []byte) error {
 	return db.db.Delete(key, nil)
 }
 
+// DeleteHM removes all fields of the hash stored at key
+func (db *LDBDatabase) DeleteHM(key []byte) error {
+	batch := db.NewBatch()
+	if err := batch.DeleteHM(key); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Type returns "string", "hash" or "none" like the redis TYPE command
+func (db *LDBDatabase) Type(key string) (string, error) {
+	ok, err := db.db.Has([]byte(key), nil)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return "string", nil
+	}
+	iter := db.db.NewIterator(util.BytesPrefix(hmPrefix([]byte(key))), nil)
+	defer iter.Release()
+	if iter.Next() {
+		return "hash", nil
+	}
+	if err := iter.Error(); err != nil {
+		return "", err
+	}
+	return "none", nil
+}
+
+// GetAll returns all fields of the hash stored at key, like the redis HGETALL command
+func (db *LDBDatabase) GetAll(key string) (map[string]string, error) {
+	prefix := hmPrefix([]byte(key))
+	all := make(map[string]string)
+	iter := db.db.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		all[string(iter.Key()[len(prefix):])] = string(iter.Value())
+	}
+	iter.Release()
+	return all, iter.Error()
+}
+
+func (db *LDBDatabase) NewBatch() Batch {
+	return &LDBBatch{db: db, batch: new(leveldb.Batch), fields: make(map[string][][]byte)}
+}
+
 func (db *LDBDatabase) NewIterator() iterator.Iterator {
 	return db.db.NewIterator(nil, nil)
 }
 
+// Iterate walks the keys in [prefix+start, prefix+end), the fields of a hash
+// show up as key + hmSeparator + field
+func (db *LDBDatabase) Iterate(prefix, start, end []byte) Iterator {
+	return db.db.NewIterator(prefixRange(prefix, start, end), nil)
+}
+
+func (db *LDBDatabase) Keys(prefix []byte) ([][]byte, error) {
+	return collectKeys(db.Iterate(prefix, nil, nil))
+}
+
 func (db *LDBDatabase) Close() {
 	db.quitLock.Lock()
 	defer db.quitLock.Unlock()
@@ -114,3 +186,54 @@ func (db *LDBDatabase) IsEmpty() (bool, error) {
 
 	return isEmpty, err
 }
+
+type LDBBatch struct {
+	db    *LDBDatabase
+	batch *leveldb.Batch
+
+	fields map[string][][]byte // fields put in the batch by hash, not in the database yet
+}
+
+func (b *LDBBatch) Put(key []byte, value []byte) error {
+	b.batch.Put(key, value)
+	return nil
+}
+
+func (b *LDBBatch) PutHM(key []byte, args ...[]byte) error {
+	if err := hmArgs(key, args); err != nil {
+		return err
+	}
+	for i := 0; i < len(args); i += 2 {
+		field := hmKey(key, args[i])
+		b.batch.Put(field, args[i+1])
+		b.fields[string(key)] = append(b.fields[string(key)], field)
+	}
+	return nil
+}
+
+func (b *LDBBatch) Delete(key []byte) error {
+	b.batch.Delete(key)
+	return nil
+}
+
+// DeleteHM removes all fields of the hash stored at key, the ones put earlier
+// in the batch included
+func (b *LDBBatch) DeleteHM(key []byte) error {
+	if len(key) == 0 {
+		return errEmptyHashKey
+	}
+	for _, field := range b.fields[string(key)] {
+		b.batch.Delete(field)
+	}
+	delete(b.fields, string(key))
+	iter := b.db.db.NewIterator(util.BytesPrefix(hmPrefix(key)), nil)
+	for iter.Next() {
+		b.batch.Delete(CopyBytes(iter.Key()))
+	}
+	iter.Release()
+	return iter.Error()
+}
+
+func (b *LDBBatch) Write() error {
+	return b.db.db.Write(b.batch, nil)
+}
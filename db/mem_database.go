@@ -1,7 +1,9 @@
 package db
 
 import (
+	"bytes"
 	"errors"
+	"sort"
 	"sync"
 )
 
@@ -15,16 +17,17 @@ func CopyBytes(b []byte) (copiedBytes []byte) {
 }
 
 type MemDatabase struct {
-	db   map[string][]byte
-	lock sync.RWMutex
+	db     map[string][]byte
+	hashes map[string]map[string]bool // fields put with PutHM by hash key, some may be deleted since
+	lock   sync.RWMutex
 }
 
 func NewMemDatabase() (*MemDatabase, error) {
-	return &MemDatabase{db: make(map[string][]byte)}, nil
+	return &MemDatabase{db: make(map[string][]byte), hashes: make(map[string]map[string]bool)}, nil
 }
 
 func NewMemDatabaseWithCap(size int) (*MemDatabase, error) {
-	return &MemDatabase{db: make(map[string][]byte, size)}, nil
+	return &MemDatabase{db: make(map[string][]byte, size), hashes: make(map[string]map[string]bool)}, nil
 }
 
 func (db *MemDatabase) Put(key []byte, value []byte) error {
@@ -35,7 +38,25 @@ func (db *MemDatabase) Put(key []byte, value []byte) error {
 }
 
 func (db *MemDatabase) PutHM(key []byte, args ...[]byte) error {
-	return errors.New("Unsupported")
+	if err := hmArgs(key, args); err != nil {
+		return err
+	}
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.putHM(key, args...)
+	return nil
+}
+
+func (db *MemDatabase) putHM(key []byte, args ...[]byte) {
+	fields, ok := db.hashes[string(key)]
+	if !ok {
+		fields = make(map[string]bool)
+		db.hashes[string(key)] = fields
+	}
+	for i := 0; i < len(args); i += 2 {
+		db.db[string(hmKey(key, args[i]))] = CopyBytes(args[i+1])
+		fields[string(args[i])] = true
+	}
 }
 
 func (db *MemDatabase) Get(key []byte) ([]byte, error) {
@@ -48,7 +69,13 @@ func (db *MemDatabase) Get(key []byte) ([]byte, error) {
 }
 
 func (db *MemDatabase) GetHM(key []byte, args ...[]byte) ([][]byte, error) {
-	return nil, errors.New("Unsupported")
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	values := make([][]byte, 0, len(args))
+	for _, field := range args {
+		values = append(values, CopyBytes(db.db[string(hmKey(key, field))]))
+	}
+	return values, nil
 }
 
 func (db *MemDatabase) Has(key []byte) (bool, error) {
@@ -58,16 +85,6 @@ func (db *MemDatabase) Has(key []byte) (bool, error) {
 	return ok, nil
 }
 
-func (db *MemDatabase) Keys() [][]byte {
-	db.lock.RLock()
-	defer db.lock.RUnlock()
-	keys := [][]byte{}
-	for key := range db.db {
-		keys = append(keys, []byte(key))
-	}
-	return keys
-}
-
 func (db *MemDatabase) Delete(key []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -75,4 +92,170 @@ func (db *MemDatabase) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteHM removes all fields of the hash stored at key
+func (db *MemDatabase) DeleteHM(key []byte) error {
+	if len(key) == 0 {
+		return errEmptyHashKey
+	}
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.deleteHM(key)
+	return nil
+}
+
+func (db *MemDatabase) deleteHM(key []byte) {
+	for f := range db.hashes[string(key)] {
+		delete(db.db, string(hmKey(key, []byte(f))))
+	}
+	delete(db.hashes, string(key))
+}
+
+// Type returns "string", "hash" or "none" like the redis TYPE command
+func (db *MemDatabase) Type(key string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	if _, ok := db.db[key]; ok {
+		return "string", nil
+	}
+	for f := range db.hashes[key] {
+		if _, ok := db.db[string(hmKey([]byte(key), []byte(f)))]; ok {
+			return "hash", nil
+		}
+	}
+	return "none", nil
+}
+
+// GetAll returns all fields of the hash stored at key, like the redis HGETALL command
+func (db *MemDatabase) GetAll(key string) (map[string]string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	all := make(map[string]string)
+	for f := range db.hashes[key] {
+		if v, ok := db.db[string(hmKey([]byte(key), []byte(f)))]; ok {
+			all[f] = string(v)
+		}
+	}
+	return all, nil
+}
+
 func (db *MemDatabase) Close() {}
+
+// Iterate walks a snapshot of the keys in [prefix+start, prefix+end), the fields
+// of a hash show up as key + hmSeparator + field
+func (db *MemDatabase) Iterate(prefix, start, end []byte) Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	r := prefixRange(prefix, start, end)
+	iter := &MemIterator{pos: -1}
+	for k := range db.db {
+		if bytes.HasPrefix([]byte(k), prefix) && inRange(r, []byte(k)) {
+			iter.keys = append(iter.keys, k)
+		}
+	}
+	sort.Strings(iter.keys)
+	for _, k := range iter.keys {
+		iter.values = append(iter.values, CopyBytes(db.db[k]))
+	}
+	return iter
+}
+
+func (db *MemDatabase) Keys(prefix []byte) ([][]byte, error) {
+	return collectKeys(db.Iterate(prefix, nil, nil))
+}
+
+func (db *MemDatabase) NewBatch() Batch {
+	return &MemBatch{db: db}
+}
+
+// MemBatch buffers writes and applies them under the database lock
+type MemBatch struct {
+	db  *MemDatabase
+	ops []func()
+}
+
+func (b *MemBatch) Put(key []byte, value []byte) error {
+	key, value = CopyBytes(key), CopyBytes(value)
+	b.ops = append(b.ops, func() {
+		b.db.db[string(key)] = value
+	})
+	return nil
+}
+
+func (b *MemBatch) PutHM(key []byte, args ...[]byte) error {
+	if err := hmArgs(key, args); err != nil {
+		return err
+	}
+	key = CopyBytes(key)
+	fields := make([][]byte, len(args))
+	for i, arg := range args {
+		fields[i] = CopyBytes(arg)
+	}
+	b.ops = append(b.ops, func() {
+		b.db.putHM(key, fields...)
+	})
+	return nil
+}
+
+func (b *MemBatch) Delete(key []byte) error {
+	key = CopyBytes(key)
+	b.ops = append(b.ops, func() {
+		delete(b.db.db, string(key))
+	})
+	return nil
+}
+
+func (b *MemBatch) DeleteHM(key []byte) error {
+	if len(key) == 0 {
+		return errEmptyHashKey
+	}
+	key = CopyBytes(key)
+	b.ops = append(b.ops, func() {
+		b.db.deleteHM(key)
+	})
+	return nil
+}
+
+func (b *MemBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+	for _, op := range b.ops {
+		op()
+	}
+	b.ops = nil
+	return nil
+}
+
+type MemIterator struct {
+	keys   []string
+	values [][]byte
+	pos    int
+}
+
+func (it *MemIterator) Next() bool {
+	if it.pos < len(it.keys) {
+		it.pos++
+	}
+	return it.pos < len(it.keys)
+}
+
+func (it *MemIterator) Key() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return []byte(it.keys[it.pos])
+}
+
+func (it *MemIterator) Value() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return it.values[it.pos]
+}
+
+func (it *MemIterator) Release() {
+	it.keys, it.values = nil, nil
+}
+
+func (it *MemIterator) Error() error {
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	db "github.com/iost-official/Go-IOS-Protocol/db"
 )
 
 // MockDatabase is a mock of Database interface
@@ -55,6 +56,18 @@ func (mr *MockDatabaseMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDatabase)(nil).Delete), arg0)
 }
 
+// DeleteHM mocks base method
+func (m *MockDatabase) DeleteHM(arg0 []byte) error {
+	ret := m.ctrl.Call(m, "DeleteHM", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHM indicates an expected call of DeleteHM
+func (mr *MockDatabaseMockRecorder) DeleteHM(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHM", reflect.TypeOf((*MockDatabase)(nil).DeleteHM), arg0)
+}
+
 // Get mocks base method
 func (m *MockDatabase) Get(arg0 []byte) ([]byte, error) {
 	ret := m.ctrl.Call(m, "Get", arg0)
@@ -99,6 +112,43 @@ func (mr *MockDatabaseMockRecorder) Has(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockDatabase)(nil).Has), arg0)
 }
 
+// Iterate mocks base method
+func (m *MockDatabase) Iterate(arg0, arg1, arg2 []byte) db.Iterator {
+	ret := m.ctrl.Call(m, "Iterate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.Iterator)
+	return ret0
+}
+
+// Iterate indicates an expected call of Iterate
+func (mr *MockDatabaseMockRecorder) Iterate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockDatabase)(nil).Iterate), arg0, arg1, arg2)
+}
+
+// Keys mocks base method
+func (m *MockDatabase) Keys(arg0 []byte) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "Keys", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Keys indicates an expected call of Keys
+func (mr *MockDatabaseMockRecorder) Keys(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockDatabase)(nil).Keys), arg0)
+}
+
+// NewBatch mocks base method
+func (m *MockDatabase) NewBatch() db.Batch {
+	ret := m.ctrl.Call(m, "NewBatch")
+	ret0, _ := ret[0].(db.Batch)
+	return ret0
+}
+
+// NewBatch indicates an expected call of NewBatch
+func (mr *MockDatabaseMockRecorder) NewBatch() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBatch", reflect.TypeOf((*MockDatabase)(nil).NewBatch))
+}
+
 // Put mocks base method
 func (m *MockDatabase) Put(arg0, arg1 []byte) error {
 	ret := m.ctrl.Call(m, "Put", arg0, arg1)
@@ -1,7 +1,10 @@
 package db
 
 import (
+	"bytes"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"errors"
@@ -103,6 +106,69 @@ func (rdb *RedisDatabase) Delete(key []byte) error {
 	return err
 }
 
+// DeleteHM removes the hash stored at key, a redis key holds either a plain
+// value or a hash so it is the same as Delete
+func (rdb *RedisDatabase) DeleteHM(key []byte) error {
+	return rdb.Delete(key)
+}
+
+// Iterate walks the keys in [prefix+start, prefix+end) found by SCAN, sorted
+// like the other backends, the value of a key that is not a string is nil
+func (rdb *RedisDatabase) Iterate(prefix, start, end []byte) Iterator {
+	iter := &RedisIterator{rdb: rdb, pos: -1}
+	keys, err := rdb.scan(prefix)
+	if err != nil {
+		iter.err = err
+		return iter
+	}
+	r := prefixRange(prefix, start, end)
+	for _, k := range keys {
+		if inRange(r, k) {
+			iter.keys = append(iter.keys, k)
+		}
+	}
+	sort.Slice(iter.keys, func(i, j int) bool {
+		return bytes.Compare(iter.keys[i], iter.keys[j]) < 0
+	})
+	return iter
+}
+
+func (rdb *RedisDatabase) Keys(prefix []byte) ([][]byte, error) {
+	return collectKeys(rdb.Iterate(prefix, nil, nil))
+}
+
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+func (rdb *RedisDatabase) scan(prefix []byte) ([][]byte, error) {
+	conn := rdb.connPool.Get()
+	defer conn.Close()
+	match := globEscaper.Replace(string(prefix)) + "*"
+	keys := make([][]byte, 0)
+	cursor := "0"
+	for {
+		rtn, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", match, "COUNT", 1000))
+		if err != nil {
+			return nil, err
+		}
+		cursor, err = redis.String(rtn[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		ks, err := redis.ByteSlices(rtn[1], nil)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, ks...)
+		if cursor == "0" {
+			return keys, nil
+		}
+	}
+}
+
+func (rdb *RedisDatabase) NewBatch() Batch {
+	return &RedisBatch{rdb: rdb}
+}
+
 func (rdb *RedisDatabase) Close() {
 	rdb.connPool.Close()
 }
@@ -126,3 +192,106 @@ func (rdb *RedisDatabase) GetAll(key string) (map[string]string, error) {
 	defer conn.Close()
 	return redis.StringMap(conn.Do("HGETALL", key))
 }
+
+// RedisBatch queues commands and sends them inside a MULTI/EXEC transaction
+type RedisBatch struct {
+	rdb  *RedisDatabase
+	cmds [][]interface{}
+}
+
+func (b *RedisBatch) Put(key []byte, value []byte) error {
+	b.cmds = append(b.cmds, []interface{}{"SET", key, value})
+	return nil
+}
+
+func (b *RedisBatch) PutHM(key []byte, args ...[]byte) error {
+	if err := hmArgs(key, args); err != nil {
+		return err
+	}
+	cmd := []interface{}{"HMSET", key}
+	for _, v := range args {
+		cmd = append(cmd, v)
+	}
+	b.cmds = append(b.cmds, cmd)
+	return nil
+}
+
+func (b *RedisBatch) Delete(key []byte) error {
+	b.cmds = append(b.cmds, []interface{}{"DEL", key})
+	return nil
+}
+
+func (b *RedisBatch) DeleteHM(key []byte) error {
+	return b.Delete(key)
+}
+
+func (b *RedisBatch) Write() error {
+	if len(b.cmds) == 0 {
+		return nil
+	}
+	conn := b.rdb.connPool.Get()
+	defer conn.Close()
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+	for _, cmd := range b.cmds {
+		if err := conn.Send(cmd[0].(string), cmd[1:]...); err != nil {
+			conn.Do("DISCARD")
+			return err
+		}
+	}
+	_, err := conn.Do("EXEC")
+	if err == nil {
+		b.cmds = nil
+	}
+	return err
+}
+
+type RedisIterator struct {
+	rdb   *RedisDatabase
+	keys  [][]byte
+	pos   int
+	value []byte
+	err   error
+}
+
+func (it *RedisIterator) Next() bool {
+	if it.err != nil || it.pos >= len(it.keys) {
+		return false
+	}
+	it.pos++
+	if it.pos >= len(it.keys) {
+		return false
+	}
+	conn := it.rdb.connPool.Get()
+	defer conn.Close()
+	value, err := redis.Bytes(conn.Do("GET", it.keys[it.pos]))
+	if _, wrongType := err.(redis.Error); err != nil && err != redis.ErrNil && !wrongType {
+		it.err = err
+		return false
+	}
+	it.value = value
+	return true
+}
+
+func (it *RedisIterator) Key() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return it.keys[it.pos]
+}
+
+func (it *RedisIterator) Value() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return it.value
+}
+
+func (it *RedisIterator) Release() {
+	it.keys, it.value = nil, nil
+}
+
+func (it *RedisIterator) Error() error {
+	return it.err
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/iost-official/Go-IOS-Protocol/db"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// migrateCmd converts a state database written in the legacy text format
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "convert the state database to the binary value encoding and decimal balances",
+	Long:  `convert every value of the state database still in the legacy text format to the binary value encoding, convert float balances to exact decimals, and rebuild the state root`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configState()
+		sdb, err := state.OpenDatabase()
+		if err != nil {
+			fmt.Println("open state database failed:", err)
+			os.Exit(1)
+		}
+		defer sdb.Close()
+
+		n, err := state.MigrateDatabase(sdb)
+		if err != nil {
+			fmt.Println("migrate failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("converted %v values\n", n)
+	},
+}
+
+// configState points the state and database packages at the configured storage
+func configState() {
+	ldbPath := viper.GetString("ldb.path")
+	state.LdbPath = ldbPath
+	if stateDB := viper.GetString("state.db"); stateDB != "" {
+		state.DBTarget = stateDB
+	}
+	db.DBAddr = viper.GetString("redis.addr")
+	db.DBPort = int16(viper.GetInt64("redis.port"))
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	prunePrefix    string
+	pruneRetention int64
+	pruneDryRun    bool
+)
+
+// pruneCmd removes deleted values, stale trie nodes and old history from the state database
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "remove dead entries and old history from the state database",
+	Long:  `remove the values left behind by deleted keys, the trie nodes of former state roots and, when the state history is enabled, the history older than the retention window. The node must be stopped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configState()
+		sdb, err := state.OpenDatabase()
+		if err != nil {
+			fmt.Println("open state database failed:", err)
+			os.Exit(1)
+		}
+		defer sdb.Close()
+
+		if viper.GetBool("state.history") {
+			if err := state.PatchDbInstance(); err != nil {
+				fmt.Println("open state history failed:", err)
+				os.Exit(1)
+			}
+			defer state.PatchDb.Close()
+		}
+
+		report, err := state.Prune(sdb, state.PatchDb, state.PruneOptions{
+			Prefix:    prunePrefix,
+			Retention: pruneRetention,
+			DryRun:    pruneDryRun,
+		})
+		if err != nil {
+			fmt.Println("prune failed:", err)
+			os.Exit(1)
+		}
+
+		verb := "removed"
+		if pruneDryRun {
+			verb = "would remove"
+		}
+		fmt.Printf("%v %v deleted values, %v trie nodes, %v history versions\n", verb, report.Keys, report.Nodes, report.History)
+		if report.Oldest >= 0 {
+			fmt.Printf("state readable from block %v\n", report.Oldest)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().StringVar(&prunePrefix, "prefix", "", "Only prune keys starting with this contract prefix")
+	pruneCmd.Flags().Int64Var(&pruneRetention, "retention", -1, "Blocks of history to keep below the current one, negative keeps all")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Report what would be removed without removing it")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/spf13/cobra"
+)
+
+// reindexCmd backfills the witness, time and tx indexes of an existing block database
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "rebuild the secondary indexes of the block database",
+	Long:  `write the witness, time slot and tx indexes for every stored block, so a block database written with block.index disabled can serve index queries. The node must be stopped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		chain, _ := openChain()
+		impl, ok := chain.(*block.ChainImpl)
+		if !ok {
+			fmt.Println("block chain does not support indexes")
+			os.Exit(1)
+		}
+
+		count, err := impl.RebuildIndexes()
+		if err != nil {
+			fmt.Println("reindex failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("indexed %v blocks\n", count)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reindexCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var rollbackHeight int64
+
+// rollbackCmd removes the blocks above a height and brings the state back to that height
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "remove the blocks above a height and revert the state",
+	Long:  `remove the blocks above the given height with their txs, receipts and index entries, and bring the state back to that height with the state history. The state history must be enabled and reach back to the height. The node must be stopped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if rollbackHeight < 0 {
+			fmt.Println("height must not be negative")
+			os.Exit(1)
+		}
+		if !viper.GetBool("state.history") {
+			fmt.Println("rollback needs state.history to be enabled")
+			os.Exit(1)
+		}
+		configState()
+		if err := state.PoolInstance(); err != nil {
+			fmt.Println("open state database failed:", err)
+			os.Exit(1)
+		}
+		if err := state.PatchDbInstance(); err != nil {
+			fmt.Println("open state history failed:", err)
+			os.Exit(1)
+		}
+		defer state.PatchDb.Close()
+
+		chain, _ := openChain()
+		length := chain.Length()
+		if err := chain.Rollback(uint64(rollbackHeight)); err != nil {
+			fmt.Println("rollback failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("removed %v blocks, chain length %v\n", length-chain.Length(), chain.Length())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().Int64Var(&rollbackHeight, "height", 0, "Number of the block to keep as the top")
+	rollbackCmd.MarkFlagRequired("height")
+}
@@ -101,13 +101,70 @@ var rootCmd = &cobra.Command{
 		ldbPath := viper.GetString("ldb.path")
 		redisAddr := viper.GetString("redis.addr")
 		redisPort := viper.GetInt64("redis.port")
+		stateDB := viper.GetString("state.db")
+		stateHistory := viper.GetBool("state.history")
+		blockIndex := viper.GetBool("block.index")
+		version2Height := block.Version2Height
+		if viper.IsSet("block.version2-height") {
+			version2Height = viper.GetInt64("block.version2-height")
+		}
+		stateRootHeight := block.StateRootHeight
+		if viper.IsSet("block.state-root-height") {
+			stateRootHeight = viper.GetInt64("block.state-root-height")
+		}
+		chainID := viper.GetInt64("tx.chain-id")
+		legacyEndHeight := tx.LegacyEndHeight
+		if viper.IsSet("tx.legacy-end-height") {
+			legacyEndHeight = viper.GetInt64("tx.legacy-end-height")
+		}
+		txPoolSize := txpool.MaxPoolSize
+		if viper.IsSet("txpool.max-size") {
+			txPoolSize = viper.GetInt("txpool.max-size")
+		}
+		accountQuota := txpool.AccountQuota
+		if viper.IsSet("txpool.account-quota") {
+			accountQuota = viper.GetInt("txpool.account-quota")
+		}
+		peerQuota := txpool.PeerQuota
+		if viper.IsSet("txpool.peer-quota") {
+			peerQuota = viper.GetInt("txpool.peer-quota")
+		}
+		txJournal := viper.GetString("txpool.journal")
 
 		log.Log.I("ldb.path: %v", ldbPath)
 		log.Log.I("redis.addr: %v", redisAddr)
 		log.Log.I("redis.port: %v", redisPort)
+		log.Log.I("state.db: %v", stateDB)
+		log.Log.I("state.history: %v", stateHistory)
+		log.Log.I("block.index: %v", blockIndex)
+		log.Log.I("block.version2-height: %v", version2Height)
+		log.Log.I("block.state-root-height: %v", stateRootHeight)
+		log.Log.I("tx.chain-id: %v", chainID)
+		log.Log.I("tx.legacy-end-height: %v", legacyEndHeight)
+		log.Log.I("txpool.max-size: %v", txPoolSize)
+		log.Log.I("txpool.account-quota: %v", accountQuota)
+		log.Log.I("txpool.peer-quota: %v", peerQuota)
+		log.Log.I("txpool.journal: %v", txJournal)
 
 		tx.LdbPath = ldbPath
+		tx.ChainID = chainID
+		tx.LegacyEndHeight = legacyEndHeight
+		txpool.MaxPoolSize = txPoolSize
+		txpool.AccountQuota = accountQuota
+		txpool.PeerQuota = peerQuota
+		txpool.JournalPath = txJournal
 		block.LdbPath = ldbPath
+		block.IndexEnabled = blockIndex
+		block.Version2Height = version2Height
+		block.StateRootHeight = stateRootHeight
+		if err := block.CheckStateRootHeight(); err != nil {
+			log.Log.E("%v, stop the program!", err)
+			os.Exit(1)
+		}
+		state.LdbPath = ldbPath
+		if stateDB != "" {
+			state.DBTarget = stateDB
+		}
 		db.DBAddr = redisAddr
 		db.DBPort = int16(redisPort)
 
@@ -123,6 +180,14 @@ var rootCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if stateHistory {
+			err = state.PatchDbInstance()
+			if err != nil {
+				log.Log.E("PatchDbInstance failed, stop the program! err:%v", err)
+				os.Exit(1)
+			}
+		}
+
 		if state.StdPool == nil {
 			log.Log.E("StdPool initialization failed, stop the program!")
 			os.Exit(1)
@@ -160,9 +225,16 @@ var rootCmd = &cobra.Command{
 		}
 
 		if bcLen > resBlockLength {
+			// blocks stored with their state patch are replayed, the rest executed again
+			next, err := block.ReplayState(blockChain, state.StdPool, resBlockLength)
+			if err != nil {
+				log.Log.E("Update StatePool failed, stop the program! err:%v", err)
+				os.Exit(1)
+			}
+			log.Log.I("Replayed StatePool, next number: %v", next)
 			var blk *block.Block
 			var i uint64
-			for i = resBlockLength; i < bcLen; i++ {
+			for i = next; i < bcLen; i++ {
 				log.Log.I("Update StatePool for number: %v", i)
 				blk = blockChain.GetBlockByNumber(i)
 				if blk == nil {
@@ -174,6 +246,8 @@ var rootCmd = &cobra.Command{
 						log.Log.E("Update StatePool failed, stop the program! err:%v", err)
 						os.Exit(1)
 					}
+					newPool.Put(state.Key("BlockNum"), state.MakeVInt(int(blk.Head.Number)))
+					newPool.Put(state.Key("BlockHash"), state.MakeVByte(blk.HeadHash()))
 					newPool.Flush()
 				} else {
 					newPool, err := blockcache.StdBlockVerifier(blk, state.StdPool)
@@ -181,6 +255,8 @@ var rootCmd = &cobra.Command{
 						log.Log.E("Update StatePool failed, stop the program! err:%v", err)
 						os.Exit(1)
 					}
+					newPool.Put(state.Key("BlockNum"), state.MakeVInt(int(blk.Head.Number)))
+					newPool.Put(state.Key("BlockHash"), state.MakeVByte(blk.HeadHash()))
 					newPool.Flush()
 				}
 			}
@@ -243,7 +319,16 @@ var rootCmd = &cobra.Command{
 		accSecKey := viper.GetString("account.sec-key")
 		//fmt.Printf("account.sec-key:  %v\n", accSecKey)
 
-		acc, err := account.NewAccount(common.Base58Decode(accSecKey))
+		accAlgorithm := common.Secp256k1
+		if viper.IsSet("account.algorithm") {
+			accAlgorithm, err = common.ParseSignAlgorithm(viper.GetString("account.algorithm"))
+			if err != nil {
+				log.Log.E("Unknown account.algorithm, stop the program! err:%v", err)
+				os.Exit(1)
+			}
+		}
+
+		acc, err := account.NewAccountWithAlgorithm(common.Base58Decode(accSecKey), accAlgorithm)
 		if err != nil {
 			log.Log.E("NewAccount failed, stop the program! err:%v", err)
 			os.Exit(1)
@@ -268,7 +353,7 @@ var rootCmd = &cobra.Command{
 			for k, v := range account.GenesisAccount {
 				ser, err := tx.Data.Spool.User(vm.IOSTAccount(k))
 				if err == nil {
-					ser.SetBalance(v)
+					ser.SetBalance(float64(v))
 				}
 
 			}
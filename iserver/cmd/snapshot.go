@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/Go-IOS-Protocol/account"
+	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/snapshot"
+	"github.com/iost-official/Go-IOS-Protocol/core/state"
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	snapshotHeight int64
+	snapshotFile   string
+)
+
+// snapshotCmd groups the export and import of state snapshots
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "export or import the state at a block",
+	Long:  `export the state at a confirmed block to a file, or bootstrap a new node from such a file. The node must be stopped.`,
+}
+
+var snapshotExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "write the state at a confirmed block to a file",
+	Long:  `write all state values, the block and its hash at the given block number, and the current servi pool to a file. Blocks below the last confirmed one need the state history.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configState()
+		sdb, err := state.OpenDatabase()
+		if err != nil {
+			fmt.Println("open state database failed:", err)
+			os.Exit(1)
+		}
+		defer sdb.Close()
+		if viper.GetBool("state.history") {
+			if err := state.PatchDbInstance(); err != nil {
+				fmt.Println("open state history failed:", err)
+				os.Exit(1)
+			}
+			defer state.PatchDb.Close()
+		}
+		chain, servi := openChain()
+
+		f, err := os.Create(snapshotFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		if err := snapshot.Export(f, sdb, state.PatchDb, chain, servi, snapshotHeight); err != nil {
+			fmt.Println("export failed:", err)
+			os.Remove(snapshotFile)
+			os.Exit(1)
+		}
+		fmt.Printf("exported the state at block %v to %v\n", snapshotHeight, snapshotFile)
+	},
+}
+
+var snapshotImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "load a state snapshot into a new node",
+	Long:  `verify a snapshot and load its state, block and servi pool into the empty databases of a new node, which then syncs from that block.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configState()
+		sdb, err := state.OpenDatabase()
+		if err != nil {
+			fmt.Println("open state database failed:", err)
+			os.Exit(1)
+		}
+		defer sdb.Close()
+		state.StdPool = state.NewPool(state.NewDatabase(sdb))
+		chain, servi := openChain()
+
+		f, err := os.Open(snapshotFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		blk, err := snapshot.Import(f, sdb, chain, servi)
+		if err != nil {
+			fmt.Println("import failed:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("imported the state at block %v\n", blk.Head.Number)
+	},
+}
+
+// openChain opens the block chain and the servi pool at the configured path
+func openChain() (block.Chain, *tx.ServiPool) {
+	ldbPath := viper.GetString("ldb.path")
+	tx.LdbPath = ldbPath
+	block.LdbPath = ldbPath
+	if tx.TxDbInstance() == nil {
+		fmt.Println("open tx database failed")
+		os.Exit(1)
+	}
+	chain, err := block.Instance()
+	if err != nil {
+		fmt.Println("open block chain failed:", err)
+		os.Exit(1)
+	}
+	servi, err := tx.NewServiPool(len(account.GenesisAccount), 100)
+	if err != nil {
+		fmt.Println("open servi pool failed:", err)
+		os.Exit(1)
+	}
+	return chain, servi
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotExportCmd)
+	snapshotCmd.AddCommand(snapshotImportCmd)
+
+	snapshotExportCmd.Flags().Int64Var(&snapshotHeight, "height", 0, "Block number of the exported state")
+	snapshotExportCmd.MarkFlagRequired("height")
+	snapshotCmd.PersistentFlags().StringVarP(&snapshotFile, "file", "f", "state.snapshot", "Snapshot file")
+}
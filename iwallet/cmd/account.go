@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/iost-official/Go-IOS-Protocol/account"
+	"github.com/iost-official/Go-IOS-Protocol/common"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 )
@@ -36,8 +37,21 @@ var accountCmd = &cobra.Command{
 			if strings.ContainsAny(nickName, `?*:|/\"`) || len(nickName) > 16 {
 				fmt.Println("invalid nick name")
 			}
-			ac, _ := account.NewAccount(nil)
-			pubfile, err := os.Create(kvPath + "/" + nickName + "_secp.pub")
+			algo, err := common.ParseSignAlgorithm(algorithm)
+			if err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+			ac, err := account.NewAccountWithAlgorithm(nil, algo)
+			if err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+			suffix := "_" + algorithm
+			if algo == common.Secp256k1 {
+				suffix = "_secp"
+			}
+			pubfile, err := os.Create(kvPath + "/" + nickName + suffix + ".pub")
 			if err != nil {
 				fmt.Println(err.Error())
 				return
@@ -50,14 +64,14 @@ var accountCmd = &cobra.Command{
 				return
 			}
 
-			secFile, err := os.Create(kvPath + "/" + nickName + "_secp")
+			secFile, err := os.Create(kvPath + "/" + nickName + suffix)
 			if err != nil {
 				fmt.Println(err.Error())
 				return
 			}
 			defer secFile.Close()
 
-			_, err = secFile.WriteString(SaveBytes(ac.Seckey))
+			_, err = secFile.WriteString(SaveBytes(ac.ExportSeckey()))
 			if err != nil {
 				fmt.Println(err.Error())
 				return
@@ -71,6 +85,7 @@ var accountCmd = &cobra.Command{
 
 var kvPath string
 var nickName string
+var algorithm string
 
 func init() {
 	rootCmd.AddCommand(accountCmd)
@@ -83,6 +98,7 @@ func init() {
 
 	accountCmd.Flags().StringVarP(&nickName, "create", "c", "id", "Create new account, using input as nickname")
 	accountCmd.Flags().StringVarP(&kvPath, "path", "p", home+"/.ssh", "Set path of key pair file")
+	accountCmd.Flags().StringVarP(&algorithm, "algorithm", "a", "secp256k1", "Set signature algorithm of new account: secp256k1, ed25519 or secp256r1")
 
 	// Here you will define your flags and configuration settings.
 
@@ -49,15 +49,20 @@ var balanceCmd = &cobra.Command{
 		b, err := CheckBalance(ia)
 		if err != nil {
 			fmt.Println(err)
+			return
 		}
 		fmt.Println(filePath, ">", b, "iost")
 
 	},
 }
 
+var balanceHeight *int64
+
 func init() {
 	rootCmd.AddCommand(balanceCmd)
 
+	balanceHeight = balanceCmd.Flags().Int64("height", 0, "Read the balance as of this block number, 0 for the latest")
+
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
@@ -69,20 +74,24 @@ func init() {
 	// balanceCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
-func CheckBalance(ia vm.IOSTAccount) (float64, error) {
+func CheckBalance(ia vm.IOSTAccount) (*state.VDecimal, error) {
 	conn, err := grpc.Dial(server, grpc.WithInsecure())
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer conn.Close()
 	client := rpc.NewCliClient(conn)
-	value, err := client.GetBalance(context.Background(), &rpc.Key{S: string(ia)})
+	value, err := client.GetBalance(context.Background(), &rpc.Key{S: string(ia), Height: *balanceHeight})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	vv, err := state.ParseValue(value.Sv)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	b, ok := vv.(*state.VDecimal)
+	if !ok {
+		return nil, fmt.Errorf("unexpected balance %v", value.Sv)
 	}
-	return vv.(*state.VFloat).ToFloat64(), nil
+	return b, nil
 }
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/iost-official/Go-IOS-Protocol/core/tx"
 	"github.com/iost-official/Go-IOS-Protocol/vm"
@@ -53,6 +54,8 @@ var compileCmd = &cobra.Command{
 		}
 
 		mTx := tx.NewTx(int64(Nonce), contract)
+		mTx.ChainID = ChainID
+		mTx.Expiration = mTx.Time + Lifetime*int64(time.Second)
 
 		bytes := mTx.Encode()
 
@@ -70,12 +73,16 @@ var compileCmd = &cobra.Command{
 var Language string
 var dest string
 var Nonce int
+var ChainID int64
+var Lifetime int64
 
 func init() {
 	rootCmd.AddCommand(compileCmd)
 
 	compileCmd.Flags().StringVarP(&Language, "language", "l", "lua", "Set language of contract, Support lua")
-	compileCmd.Flags().IntVarP(&Nonce, "nonce", "n", 1, "Set Nonce of this Transaction")
+	compileCmd.Flags().IntVarP(&Nonce, "nonce", "n", 0, "Set Nonce of this Transaction, see iwallet nonce")
+	compileCmd.Flags().Int64VarP(&ChainID, "chain-id", "c", 0, "Set the chain id of the network this Transaction is for")
+	compileCmd.Flags().Int64VarP(&Lifetime, "lifetime", "t", int64(tx.DefaultLifetime/time.Second), "Set seconds until this Transaction expires")
 
 	// Here you will define your flags and configuration settings.
 
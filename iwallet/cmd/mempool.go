@@ -0,0 +1,152 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/iost-official/Go-IOS-Protocol/core/tx"
+	"github.com/iost-official/Go-IOS-Protocol/rpc"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// mempoolCmd represents the mempool command
+var mempoolCmd = &cobra.Command{
+	Use:   "mempool",
+	Short: "inspect the transactions waiting in the tx pool of the server",
+	Long: `inspect the transactions waiting in the tx pool of the server, by default list them the best paying first
+	iwallet mempool -p <account> --offset 100 --limit 50	list a page of the transactions of an account
+	iwallet mempool --hash <tx hash>			print a pending transaction
+	iwallet mempool -p <account> --queue			print the queue state of an account
+	iwallet mempool --watch					print the transactions admitted from now on`,
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := grpc.Dial(server, grpc.WithInsecure())
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		defer conn.Close()
+		client := rpc.NewCliClient(conn)
+
+		switch {
+		case *mempoolHash != "":
+			err = printPendingTx(client, LoadBytes(*mempoolHash))
+		case *mempoolQueue:
+			err = printAccountQueue(client, *mempoolPublisher)
+		case *mempoolWatch:
+			err = watchPendingTxs(client, *mempoolPublisher)
+		default:
+			err = listPendingTxs(client, *mempoolPublisher, *mempoolOffset, *mempoolLimit)
+		}
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+	},
+}
+
+var mempoolPublisher *string
+var mempoolOffset *int64
+var mempoolLimit *int64
+var mempoolHash *string
+var mempoolQueue *bool
+var mempoolWatch *bool
+
+func init() {
+	rootCmd.AddCommand(mempoolCmd)
+
+	mempoolPublisher = mempoolCmd.Flags().StringP("publisher", "p", "", "only the transactions of this account")
+	mempoolOffset = mempoolCmd.Flags().Int64("offset", 0, "skip this number of transactions")
+	mempoolLimit = mempoolCmd.Flags().Int64("limit", 0, "list at most this number of transactions, 0 for the server default")
+	mempoolHash = mempoolCmd.Flags().String("hash", "", "print the pending transaction with this hash")
+	mempoolQueue = mempoolCmd.Flags().Bool("queue", false, "print the queue state of the account given with --publisher")
+	mempoolWatch = mempoolCmd.Flags().Bool("watch", false, "print the transactions admitted to the pool until interrupted")
+}
+
+func decodeTx(raw *rpc.Transaction) (tx.Tx, error) {
+	var t tx.Tx
+	err := t.Decode(raw.Tx)
+	return t, err
+}
+
+func listPendingTxs(client rpc.CliClient, publisher string, offset, limit int64) error {
+	page, err := client.GetPendingTxs(context.Background(), &rpc.PendingTxsKey{Publisher: publisher, Offset: offset, Limit: limit})
+	if err != nil {
+		return err
+	}
+	for _, raw := range page.Txs {
+		t, err := decodeTx(raw)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v\t%v\tprice %v\n", SaveBytes(t.Hash()), t.Nonce, t.Contract.Info().Price)
+	}
+	fmt.Printf("%v to %v of %v transactions\n", offset, offset+int64(len(page.Txs)), page.Total)
+	return nil
+}
+
+func printPendingTx(client rpc.CliClient, hash []byte) error {
+	raw, err := client.GetPendingTx(context.Background(), &rpc.TransactionHash{Hash: hash})
+	if err != nil {
+		return err
+	}
+	t, err := decodeTx(raw)
+	if err != nil {
+		return err
+	}
+	PrintTx(t)
+	return nil
+}
+
+func printAccountQueue(client rpc.CliClient, account string) error {
+	if account == "" {
+		return fmt.Errorf("input the account with --publisher")
+	}
+	q, err := client.GetAccountQueue(context.Background(), &rpc.Key{S: account})
+	if err != nil {
+		return err
+	}
+	fmt.Printf(`Account: %v
+Last nonce: %v
+Next nonce: %v
+Ready: %v
+In pool: %v
+Queued nonces: %v
+`, account, q.Last, q.Next, q.Ready, q.Count, q.Nonces)
+	return nil
+}
+
+func watchPendingTxs(client rpc.CliClient, publisher string) error {
+	stream, err := client.SubscribePendingTxs(context.Background(), &rpc.TxFilter{Publisher: publisher})
+	if err != nil {
+		return err
+	}
+	for {
+		raw, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		t, err := decodeTx(raw)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v\t%v\tprice %v\n", SaveBytes(t.Hash()), t.Nonce, t.Contract.Info().Price)
+	}
+}
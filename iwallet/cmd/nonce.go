@@ -0,0 +1,69 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"context"
+
+	"github.com/iost-official/Go-IOS-Protocol/rpc"
+	"github.com/iost-official/Go-IOS-Protocol/vm"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// nonceCmd represents the nonce command
+var nonceCmd = &cobra.Command{
+	Use:   "nonce",
+	Short: "check the next nonce of specified account",
+	Long:  `check the next nonce of specified account, the nonces of an account start at 0 and grow by one with each transaction`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var filePath string
+		if len(args) < 1 {
+			filePath = "~/.ssh/id_secp.pub"
+		} else {
+			filePath = args[0]
+		}
+		pubkey, err := ReadFile(filePath)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+
+		pk := LoadBytes(string(pubkey))
+		ia := vm.PubkeyToIOSTAccount(pk)
+		n, err := CheckNonce(ia)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(filePath, "> next nonce:", n.Next)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(nonceCmd)
+}
+
+func CheckNonce(ia vm.IOSTAccount) (*rpc.Nonce, error) {
+	conn, err := grpc.Dial(server, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := rpc.NewCliClient(conn)
+	return client.GetNonce(context.Background(), &rpc.Key{S: string(ia)})
+}
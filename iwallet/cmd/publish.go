@@ -83,7 +83,7 @@ var publishCmd = &cobra.Command{
 			return
 		}
 
-		acc, err := account.NewAccount(LoadBytes(string(fsk)))
+		acc, err := account.ImportAccount(LoadBytes(string(fsk)))
 		if err != nil {
 			fmt.Println(err.Error())
 			return
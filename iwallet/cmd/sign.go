@@ -69,7 +69,7 @@ var signCmd = &cobra.Command{
 			return
 		}
 
-		acc, err := account.NewAccount(LoadBytes(string(seckey)))
+		acc, err := account.ImportAccount(LoadBytes(string(seckey)))
 		if err != nil {
 			fmt.Println(err.Error())
 			return
@@ -40,7 +40,7 @@ var valueCmd = &cobra.Command{
 		client := rpc.NewCliClient(conn)
 		for _, arg := range args {
 			key := *prefix + arg
-			st, err := client.GetState(context.Background(), &rpc.Key{S: key})
+			st, err := client.GetState(context.Background(), &rpc.Key{S: key, Height: *valueHeight})
 			if err != nil {
 				fmt.Println(err.Error())
 				return
@@ -52,11 +52,13 @@ var valueCmd = &cobra.Command{
 }
 
 var prefix *string
+var valueHeight *int64
 
 func init() {
 	rootCmd.AddCommand(valueCmd)
 
 	prefix = valueCmd.Flags().StringP("prefix", "p", "", "Set prefix of key")
+	valueHeight = valueCmd.Flags().Int64("height", 0, "Read the value as of this block number, 0 for the latest")
 
 	// Here you will define your flags and configuration settings.
 
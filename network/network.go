@@ -429,17 +429,16 @@ func (bn *BaseNetwork) AllNodesExcludeAddr(excludeAddr string) ([]string, error)
 		return nil, nil
 	}
 	addrs := make([]string, 0)
-	iter := bn.nodeTable.NewIterator()
-	for iter.Next() {
-		addr := string(iter.Key())
+	keys, err := bn.nodeTable.Keys(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		addr := string(k)
 		if addr != excludeAddr {
 			addrs = append(addrs, addr)
 		}
 	}
-	iter.Release()
-	if err := iter.Error(); err != nil {
-		return nil, err
-	}
 
 	return addrs, nil
 }
@@ -476,7 +475,7 @@ func (bn *BaseNetwork) putNode(addrs string) {
 func (bn *BaseNetwork) nodeCheckLoop() {
 	if bn.localNode.TCP == RegisterServerPort {
 		for {
-			iter := bn.nodeTable.NewIterator()
+			iter := bn.nodeTable.Iterate(nil, nil, nil)
 			for iter.Next() {
 				k := iter.Key()
 				v := common.BytesToInt(iter.Value())
@@ -490,6 +489,7 @@ func (bn *BaseNetwork) nodeCheckLoop() {
 					bn.nodeTable.Put(k, common.IntToBytes(v-1))
 				}
 			}
+			iter.Release()
 			time.Sleep(CheckKnownNodeInterval * time.Second)
 		}
 	}
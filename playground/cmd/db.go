@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"strings"
+
+	"github.com/iost-official/Go-IOS-Protocol/db"
 )
 
 type Database struct {
@@ -34,8 +36,35 @@ func (d *Database) Delete(key []byte) error {
 	delete(d.Normal, string(key))
 	return nil
 }
+func (d *Database) DeleteHM(key []byte) error {
+	for k := range d.Normal {
+		if strings.HasPrefix(k, string(key)+".") {
+			delete(d.Normal, k)
+		}
+	}
+	return nil
+}
 func (d *Database) Close() {
 }
+func (d *Database) NewBatch() db.Batch {
+	return &batch{d}
+}
+func (d *Database) Iterate(prefix, start, end []byte) db.Iterator {
+	mdb, _ := db.NewMemDatabase()
+	for k, v := range d.Normal {
+		mdb.Put([]byte(k), v)
+	}
+	return mdb.Iterate(prefix, start, end)
+}
+func (d *Database) Keys(prefix []byte) ([][]byte, error) {
+	keys := make([][]byte, 0)
+	for k := range d.Normal {
+		if strings.HasPrefix(k, string(prefix)) {
+			keys = append(keys, []byte(k))
+		}
+	}
+	return keys, nil
+}
 func (d *Database) Type(key string) (string, error) {
 	for k := range d.Normal {
 		if strings.HasPrefix(k, key+".") {
@@ -54,3 +83,12 @@ func (d *Database) GetAll(key string) (map[string]string, error) {
 	}
 	return mm, nil
 }
+
+// batch writes straight through, the playground runs a single contract at a time
+type batch struct {
+	*Database
+}
+
+func (b *batch) Write() error {
+	return nil
+}
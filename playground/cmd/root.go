@@ -141,11 +141,11 @@ Playground runs lua script by turns.
 
 		var ss []string
 		for k, v := range db.Normal {
-			if strings.HasPrefix(k, "context.") {
+			if strings.HasPrefix(k, "context.") || strings.HasPrefix(k, "\x00") {
 				continue
 			}
 			var vs string
-			val, _ := state.ParseValue(string(v))
+			val, _ := state.DecodeValue(v)
 			switch val.(type) {
 			case *state.VBool:
 				vs = "(bool) "
@@ -153,6 +153,9 @@ Playground runs lua script by turns.
 			case *state.VFloat:
 				vs = "(float) "
 				vs += strconv.FormatFloat(val.(*state.VFloat).ToFloat64(), 'f', 6, 64)
+			case *state.VDecimal:
+				vs = "(decimal) "
+				vs += val.(*state.VDecimal).String()
 			case *state.VMap:
 				vs = "(map) "
 				vs += val.EncodeString() + "}"
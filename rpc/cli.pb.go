@@ -285,6 +285,7 @@ func (m *TransactionHash) GetHash() []byte {
 
 type Key struct {
 	S                    string   `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	Height               int64    `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -321,6 +322,13 @@ func (m *Key) GetS() string {
 	return ""
 }
 
+func (m *Key) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type Value struct {
 	Sv                   string   `protobuf:"bytes,2,opt,name=sv" json:"sv,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -407,6 +415,10 @@ type Head struct {
 	Witness              string   `protobuf:"bytes,7,opt,name=witness" json:"witness,omitempty"`
 	Signature            []byte   `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
 	Time                 int64    `protobuf:"varint,9,opt,name=time" json:"time,omitempty"`
+	ReceiptsRoot         []byte   `protobuf:"bytes,10,opt,name=receiptsRoot,proto3" json:"receiptsRoot,omitempty"`
+	StateRoot            []byte   `protobuf:"bytes,11,opt,name=stateRoot,proto3" json:"stateRoot,omitempty"`
+	GasUsed              int64    `protobuf:"varint,12,opt,name=gasUsed" json:"gasUsed,omitempty"`
+	TxCount              int64    `protobuf:"varint,13,opt,name=txCount" json:"txCount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -499,6 +511,34 @@ func (m *Head) GetTime() int64 {
 	return 0
 }
 
+func (m *Head) GetReceiptsRoot() []byte {
+	if m != nil {
+		return m.ReceiptsRoot
+	}
+	return nil
+}
+
+func (m *Head) GetStateRoot() []byte {
+	if m != nil {
+		return m.StateRoot
+	}
+	return nil
+}
+
+func (m *Head) GetGasUsed() int64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *Head) GetTxCount() int64 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
 type BlockInfo struct {
 	Head                 *Head             `protobuf:"bytes,1,opt,name=head" json:"head,omitempty"`
 	Txcnt                int64             `protobuf:"varint,2,opt,name=Txcnt" json:"Txcnt,omitempty"`
@@ -553,6 +593,376 @@ func (m *BlockInfo) GetTxList() []*TransactionKey {
 	return nil
 }
 
+type Receipt struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Status               int32    `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
+	GasUsed              int64    `protobuf:"varint,3,opt,name=gasUsed" json:"gasUsed,omitempty"`
+	Fee                  string   `protobuf:"bytes,4,opt,name=fee" json:"fee,omitempty"`
+	Logs                 []string `protobuf:"bytes,5,rep,name=logs" json:"logs,omitempty"`
+	Error                string   `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{11}
+}
+func (m *Receipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Receipt.Unmarshal(m, b)
+}
+func (m *Receipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Receipt.Marshal(b, m, deterministic)
+}
+func (dst *Receipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Receipt.Merge(dst, src)
+}
+func (m *Receipt) XXX_Size() int {
+	return xxx_messageInfo_Receipt.Size(m)
+}
+func (m *Receipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_Receipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Receipt proto.InternalMessageInfo
+
+func (m *Receipt) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *Receipt) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *Receipt) GetGasUsed() int64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *Receipt) GetFee() string {
+	if m != nil {
+		return m.Fee
+	}
+	return ""
+}
+
+func (m *Receipt) GetLogs() []string {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *Receipt) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ReceiptList struct {
+	Receipts             []*Receipt `protobuf:"bytes,1,rep,name=receipts" json:"receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReceiptList) Reset()         { *m = ReceiptList{} }
+func (m *ReceiptList) String() string { return proto.CompactTextString(m) }
+func (*ReceiptList) ProtoMessage()    {}
+func (*ReceiptList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{12}
+}
+func (m *ReceiptList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptList.Unmarshal(m, b)
+}
+func (m *ReceiptList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptList.Marshal(b, m, deterministic)
+}
+func (dst *ReceiptList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptList.Merge(dst, src)
+}
+func (m *ReceiptList) XXX_Size() int {
+	return xxx_messageInfo_ReceiptList.Size(m)
+}
+func (m *ReceiptList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptList proto.InternalMessageInfo
+
+func (m *ReceiptList) GetReceipts() []*Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+type Nonce struct {
+	Last                 int64    `protobuf:"varint,1,opt,name=last" json:"last,omitempty"`
+	Next                 int64    `protobuf:"varint,2,opt,name=next" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Nonce) Reset()         { *m = Nonce{} }
+func (m *Nonce) String() string { return proto.CompactTextString(m) }
+func (*Nonce) ProtoMessage()    {}
+func (*Nonce) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{13}
+}
+func (m *Nonce) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Nonce.Unmarshal(m, b)
+}
+func (m *Nonce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Nonce.Marshal(b, m, deterministic)
+}
+func (dst *Nonce) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Nonce.Merge(dst, src)
+}
+func (m *Nonce) XXX_Size() int {
+	return xxx_messageInfo_Nonce.Size(m)
+}
+func (m *Nonce) XXX_DiscardUnknown() {
+	xxx_messageInfo_Nonce.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Nonce proto.InternalMessageInfo
+
+func (m *Nonce) GetLast() int64 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *Nonce) GetNext() int64 {
+	if m != nil {
+		return m.Next
+	}
+	return 0
+}
+
+type PendingTxsKey struct {
+	Publisher            string   `protobuf:"bytes,1,opt,name=publisher" json:"publisher,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Limit                int64    `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingTxsKey) Reset()         { *m = PendingTxsKey{} }
+func (m *PendingTxsKey) String() string { return proto.CompactTextString(m) }
+func (*PendingTxsKey) ProtoMessage()    {}
+func (*PendingTxsKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{14}
+}
+func (m *PendingTxsKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingTxsKey.Unmarshal(m, b)
+}
+func (m *PendingTxsKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingTxsKey.Marshal(b, m, deterministic)
+}
+func (dst *PendingTxsKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingTxsKey.Merge(dst, src)
+}
+func (m *PendingTxsKey) XXX_Size() int {
+	return xxx_messageInfo_PendingTxsKey.Size(m)
+}
+func (m *PendingTxsKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingTxsKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingTxsKey proto.InternalMessageInfo
+
+func (m *PendingTxsKey) GetPublisher() string {
+	if m != nil {
+		return m.Publisher
+	}
+	return ""
+}
+
+func (m *PendingTxsKey) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *PendingTxsKey) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PendingTxs struct {
+	Total                int64          `protobuf:"varint,1,opt,name=total" json:"total,omitempty"`
+	Txs                  []*Transaction `protobuf:"bytes,2,rep,name=txs" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PendingTxs) Reset()         { *m = PendingTxs{} }
+func (m *PendingTxs) String() string { return proto.CompactTextString(m) }
+func (*PendingTxs) ProtoMessage()    {}
+func (*PendingTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{15}
+}
+func (m *PendingTxs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingTxs.Unmarshal(m, b)
+}
+func (m *PendingTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingTxs.Marshal(b, m, deterministic)
+}
+func (dst *PendingTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingTxs.Merge(dst, src)
+}
+func (m *PendingTxs) XXX_Size() int {
+	return xxx_messageInfo_PendingTxs.Size(m)
+}
+func (m *PendingTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingTxs proto.InternalMessageInfo
+
+func (m *PendingTxs) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *PendingTxs) GetTxs() []*Transaction {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+type AccountQueue struct {
+	Last                 int64    `protobuf:"varint,1,opt,name=last" json:"last,omitempty"`
+	Next                 int64    `protobuf:"varint,2,opt,name=next" json:"next,omitempty"`
+	Ready                int64    `protobuf:"varint,3,opt,name=ready" json:"ready,omitempty"`
+	Count                int64    `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+	Nonces               []int64  `protobuf:"varint,5,rep,name=nonces,packed" json:"nonces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountQueue) Reset()         { *m = AccountQueue{} }
+func (m *AccountQueue) String() string { return proto.CompactTextString(m) }
+func (*AccountQueue) ProtoMessage()    {}
+func (*AccountQueue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{16}
+}
+func (m *AccountQueue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountQueue.Unmarshal(m, b)
+}
+func (m *AccountQueue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountQueue.Marshal(b, m, deterministic)
+}
+func (dst *AccountQueue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountQueue.Merge(dst, src)
+}
+func (m *AccountQueue) XXX_Size() int {
+	return xxx_messageInfo_AccountQueue.Size(m)
+}
+func (m *AccountQueue) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountQueue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountQueue proto.InternalMessageInfo
+
+func (m *AccountQueue) GetLast() int64 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *AccountQueue) GetNext() int64 {
+	if m != nil {
+		return m.Next
+	}
+	return 0
+}
+
+func (m *AccountQueue) GetReady() int64 {
+	if m != nil {
+		return m.Ready
+	}
+	return 0
+}
+
+func (m *AccountQueue) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AccountQueue) GetNonces() []int64 {
+	if m != nil {
+		return m.Nonces
+	}
+	return nil
+}
+
+type TxFilter struct {
+	Publisher            string   `protobuf:"bytes,1,opt,name=publisher" json:"publisher,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxFilter) Reset()         { *m = TxFilter{} }
+func (m *TxFilter) String() string { return proto.CompactTextString(m) }
+func (*TxFilter) ProtoMessage()    {}
+func (*TxFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cli_441c81ee1c4e0f3c, []int{17}
+}
+func (m *TxFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxFilter.Unmarshal(m, b)
+}
+func (m *TxFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxFilter.Marshal(b, m, deterministic)
+}
+func (dst *TxFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxFilter.Merge(dst, src)
+}
+func (m *TxFilter) XXX_Size() int {
+	return xxx_messageInfo_TxFilter.Size(m)
+}
+func (m *TxFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxFilter proto.InternalMessageInfo
+
+func (m *TxFilter) GetPublisher() string {
+	if m != nil {
+		return m.Publisher
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TransInfo)(nil), "rpc.TransInfo")
 	proto.RegisterType((*Transaction)(nil), "rpc.Transaction")
@@ -565,6 +975,13 @@ func init() {
 	proto.RegisterType((*BlockKey)(nil), "rpc.BlockKey")
 	proto.RegisterType((*Head)(nil), "rpc.Head")
 	proto.RegisterType((*BlockInfo)(nil), "rpc.BlockInfo")
+	proto.RegisterType((*Receipt)(nil), "rpc.Receipt")
+	proto.RegisterType((*ReceiptList)(nil), "rpc.ReceiptList")
+	proto.RegisterType((*Nonce)(nil), "rpc.Nonce")
+	proto.RegisterType((*PendingTxsKey)(nil), "rpc.PendingTxsKey")
+	proto.RegisterType((*PendingTxs)(nil), "rpc.PendingTxs")
+	proto.RegisterType((*AccountQueue)(nil), "rpc.AccountQueue")
+	proto.RegisterType((*TxFilter)(nil), "rpc.TxFilter")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -587,6 +1004,13 @@ type CliClient interface {
 	GetBlock(ctx context.Context, in *BlockKey, opts ...grpc.CallOption) (*BlockInfo, error)
 	GetBlockByHeight(ctx context.Context, in *BlockKey, opts ...grpc.CallOption) (*BlockInfo, error)
 	Transfer(ctx context.Context, in *TransInfo, opts ...grpc.CallOption) (*PublishRet, error)
+	GetReceipt(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Receipt, error)
+	GetBlockReceipts(ctx context.Context, in *BlockKey, opts ...grpc.CallOption) (*ReceiptList, error)
+	GetNonce(ctx context.Context, in *Key, opts ...grpc.CallOption) (*Nonce, error)
+	GetPendingTxs(ctx context.Context, in *PendingTxsKey, opts ...grpc.CallOption) (*PendingTxs, error)
+	GetPendingTx(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Transaction, error)
+	GetAccountQueue(ctx context.Context, in *Key, opts ...grpc.CallOption) (*AccountQueue, error)
+	SubscribePendingTxs(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (Cli_SubscribePendingTxsClient, error)
 }
 
 type cliClient struct {
@@ -669,6 +1093,92 @@ func (c *cliClient) Transfer(ctx context.Context, in *TransInfo, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *cliClient) GetReceipt(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Receipt, error) {
+	out := new(Receipt)
+	err := c.cc.Invoke(ctx, "/rpc.Cli/GetReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliClient) GetBlockReceipts(ctx context.Context, in *BlockKey, opts ...grpc.CallOption) (*ReceiptList, error) {
+	out := new(ReceiptList)
+	err := c.cc.Invoke(ctx, "/rpc.Cli/GetBlockReceipts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliClient) GetNonce(ctx context.Context, in *Key, opts ...grpc.CallOption) (*Nonce, error) {
+	out := new(Nonce)
+	err := c.cc.Invoke(ctx, "/rpc.Cli/GetNonce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliClient) GetPendingTxs(ctx context.Context, in *PendingTxsKey, opts ...grpc.CallOption) (*PendingTxs, error) {
+	out := new(PendingTxs)
+	err := c.cc.Invoke(ctx, "/rpc.Cli/GetPendingTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliClient) GetPendingTx(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/rpc.Cli/GetPendingTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliClient) GetAccountQueue(ctx context.Context, in *Key, opts ...grpc.CallOption) (*AccountQueue, error) {
+	out := new(AccountQueue)
+	err := c.cc.Invoke(ctx, "/rpc.Cli/GetAccountQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliClient) SubscribePendingTxs(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (Cli_SubscribePendingTxsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Cli_serviceDesc.Streams[0], "/rpc.Cli/SubscribePendingTxs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cliSubscribePendingTxsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cli_SubscribePendingTxsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type cliSubscribePendingTxsClient struct {
+	grpc.ClientStream
+}
+
+func (x *cliSubscribePendingTxsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Cli service
 
 type CliServer interface {
@@ -680,6 +1190,13 @@ type CliServer interface {
 	GetBlock(context.Context, *BlockKey) (*BlockInfo, error)
 	GetBlockByHeight(context.Context, *BlockKey) (*BlockInfo, error)
 	Transfer(context.Context, *TransInfo) (*PublishRet, error)
+	GetReceipt(context.Context, *TransactionHash) (*Receipt, error)
+	GetBlockReceipts(context.Context, *BlockKey) (*ReceiptList, error)
+	GetNonce(context.Context, *Key) (*Nonce, error)
+	GetPendingTxs(context.Context, *PendingTxsKey) (*PendingTxs, error)
+	GetPendingTx(context.Context, *TransactionHash) (*Transaction, error)
+	GetAccountQueue(context.Context, *Key) (*AccountQueue, error)
+	SubscribePendingTxs(*TxFilter, Cli_SubscribePendingTxsServer) error
 }
 
 func RegisterCliServer(s *grpc.Server, srv CliServer) {
@@ -830,6 +1347,135 @@ func _Cli_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cli_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Cli/GetReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliServer).GetReceipt(ctx, req.(*TransactionHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cli_GetBlockReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliServer).GetBlockReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Cli/GetBlockReceipts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliServer).GetBlockReceipts(ctx, req.(*BlockKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cli_GetNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Key)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliServer).GetNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Cli/GetNonce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliServer).GetNonce(ctx, req.(*Key))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cli_GetPendingTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingTxsKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliServer).GetPendingTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Cli/GetPendingTxs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliServer).GetPendingTxs(ctx, req.(*PendingTxsKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cli_GetPendingTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliServer).GetPendingTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Cli/GetPendingTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliServer).GetPendingTx(ctx, req.(*TransactionHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cli_GetAccountQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Key)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliServer).GetAccountQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Cli/GetAccountQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliServer).GetAccountQueue(ctx, req.(*Key))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cli_SubscribePendingTxs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxFilter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CliServer).SubscribePendingTxs(m, &cliSubscribePendingTxsServer{stream})
+}
+
+type Cli_SubscribePendingTxsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type cliSubscribePendingTxsServer struct {
+	grpc.ServerStream
+}
+
+func (x *cliSubscribePendingTxsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Cli_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpc.Cli",
 	HandlerType: (*CliServer)(nil),
@@ -866,49 +1512,102 @@ var _Cli_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Transfer",
 			Handler:    _Cli_Transfer_Handler,
 		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _Cli_GetReceipt_Handler,
+		},
+		{
+			MethodName: "GetBlockReceipts",
+			Handler:    _Cli_GetBlockReceipts_Handler,
+		},
+		{
+			MethodName: "GetNonce",
+			Handler:    _Cli_GetNonce_Handler,
+		},
+		{
+			MethodName: "GetPendingTxs",
+			Handler:    _Cli_GetPendingTxs_Handler,
+		},
+		{
+			MethodName: "GetPendingTx",
+			Handler:    _Cli_GetPendingTx_Handler,
+		},
+		{
+			MethodName: "GetAccountQueue",
+			Handler:    _Cli_GetAccountQueue_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribePendingTxs",
+			Handler:       _Cli_SubscribePendingTxs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "cli.proto",
 }
 
 func init() { proto.RegisterFile("cli.proto", fileDescriptor_cli_441c81ee1c4e0f3c) }
 
 var fileDescriptor_cli_441c81ee1c4e0f3c = []byte{
-	// 572 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x5d, 0x6f, 0x12, 0x41,
-	0x14, 0x65, 0x59, 0xa0, 0xbb, 0xb7, 0x95, 0x36, 0xd3, 0x46, 0x37, 0xc4, 0x36, 0xcd, 0x44, 0x13,
-	0x92, 0x46, 0x62, 0xa8, 0x2f, 0xbe, 0x19, 0x34, 0x01, 0x53, 0x1f, 0xcc, 0x8a, 0xbe, 0x0f, 0xcb,
-	0xa5, 0x3b, 0xe9, 0x32, 0xbb, 0x99, 0x19, 0x10, 0x7e, 0x85, 0xff, 0xd5, 0x5f, 0x60, 0xe6, 0xee,
-	0xf2, 0xd1, 0x8a, 0xf1, 0x6d, 0xce, 0xfd, 0x3a, 0xf7, 0x9e, 0x3d, 0x00, 0x61, 0x92, 0xc9, 0x5e,
-	0xa1, 0x73, 0x9b, 0x33, 0x5f, 0x17, 0x09, 0xff, 0x0e, 0xe1, 0x58, 0x0b, 0x65, 0x3e, 0xab, 0x59,
-	0xce, 0x9e, 0x43, 0xcb, 0x60, 0xf2, 0x80, 0xeb, 0xc8, 0xbb, 0xf6, 0xba, 0x61, 0x5c, 0x21, 0x76,
-	0x01, 0x4d, 0x95, 0xab, 0x04, 0xa3, 0xfa, 0xb5, 0xd7, 0xf5, 0xe3, 0x12, 0xb0, 0x0e, 0x04, 0x49,
-	0xae, 0xac, 0x16, 0x89, 0x8d, 0x7c, 0xaa, 0xdf, 0x62, 0x7e, 0x09, 0xc7, 0x34, 0x56, 0x24, 0x56,
-	0xe6, 0x8a, 0xb5, 0xa1, 0x6e, 0x57, 0x34, 0xf4, 0x24, 0xae, 0xdb, 0x15, 0x7f, 0x07, 0xf0, 0x75,
-	0x31, 0xc9, 0xa4, 0x49, 0x63, 0xb4, 0x8c, 0x41, 0x23, 0xc9, 0xa7, 0x48, 0xf9, 0x66, 0x4c, 0x6f,
-	0x17, 0x4b, 0x85, 0x49, 0x89, 0xf1, 0x24, 0xa6, 0x37, 0xbf, 0x82, 0x20, 0x46, 0x53, 0xe4, 0xca,
-	0xe0, 0xa1, 0x1e, 0xfe, 0x09, 0xda, 0x7b, 0xa4, 0x77, 0xb8, 0x66, 0x2f, 0x21, 0x2c, 0x4a, 0x1e,
-	0xd4, 0x15, 0xfd, 0x2e, 0x70, 0xf8, 0x2c, 0xfe, 0x1a, 0x4e, 0xf7, 0xa6, 0x8c, 0x84, 0x49, 0xb7,
-	0xcb, 0x78, 0x7b, 0xcb, 0x9c, 0x83, 0xef, 0x18, 0x4e, 0xc0, 0x33, 0x95, 0x5a, 0x9e, 0xe1, 0x2f,
-	0xa0, 0xf9, 0x43, 0x64, 0x0b, 0x74, 0x07, 0x9b, 0x25, 0xcd, 0x0d, 0xe3, 0xba, 0x59, 0xf2, 0x6b,
-	0x08, 0x06, 0x59, 0x9e, 0x3c, 0xdc, 0x95, 0x6a, 0x66, 0x62, 0x5d, 0x2d, 0xe4, 0xc7, 0x25, 0xe0,
-	0xbf, 0x3d, 0x68, 0x8c, 0x50, 0x4c, 0x59, 0x04, 0x47, 0x4b, 0xd4, 0x46, 0xe6, 0xaa, 0x2a, 0xd8,
-	0x40, 0x76, 0x05, 0x50, 0x08, 0x8d, 0xca, 0x8e, 0x76, 0xca, 0xec, 0x45, 0xdc, 0x07, 0xb1, 0x1a,
-	0x91, 0xb2, 0x3e, 0x65, 0xb7, 0xd8, 0x29, 0x31, 0x71, 0x0b, 0x50, 0xb2, 0x51, 0x2a, 0xb1, 0x0d,
-	0xb8, 0x03, 0xa5, 0x9a, 0xe5, 0x51, 0xb3, 0x3c, 0x50, 0x56, 0x66, 0x50, 0x8b, 0xf9, 0x04, 0x75,
-	0xd4, 0xa2, 0x35, 0x2a, 0xe4, 0xf6, 0xfb, 0x29, 0xad, 0x42, 0x63, 0xa2, 0x23, 0xba, 0x6f, 0x03,
-	0x1d, 0x87, 0x91, 0xf7, 0x4a, 0xd8, 0x85, 0xc6, 0x28, 0x28, 0x39, 0xb6, 0x01, 0xc7, 0x61, 0xe5,
-	0x1c, 0xa3, 0x90, 0xa6, 0xd1, 0x9b, 0xcf, 0x21, 0x24, 0x59, 0xc8, 0x7d, 0x97, 0xd0, 0x48, 0x51,
-	0x4c, 0xe9, 0xea, 0xe3, 0x7e, 0xd8, 0xd3, 0x45, 0xd2, 0x73, 0x8a, 0xc4, 0x14, 0x76, 0xb2, 0x8d,
-	0x57, 0x89, 0xb2, 0x9b, 0xaf, 0x45, 0x80, 0xdd, 0x40, 0xcb, 0xae, 0xbe, 0x48, 0xe3, 0x2c, 0xe8,
-	0x77, 0x8f, 0xfb, 0xe7, 0xd4, 0xf6, 0xd8, 0x06, 0x71, 0x55, 0xd2, 0xff, 0xe5, 0x83, 0xff, 0x31,
-	0x93, 0xec, 0x2d, 0x84, 0x95, 0xfd, 0xc6, 0x2b, 0x76, 0xf6, 0xb4, 0xa3, 0x73, 0x4a, 0x91, 0x9d,
-	0x41, 0x79, 0x8d, 0xbd, 0x87, 0xf6, 0x10, 0xed, 0xbe, 0xa5, 0x0f, 0x11, 0x75, 0xfe, 0x9a, 0xc5,
-	0x6b, 0xec, 0x03, 0x5c, 0x3c, 0x6e, 0x1d, 0xac, 0x49, 0xf3, 0x8b, 0xa7, 0xb5, 0x2e, 0x7a, 0x70,
-	0xc2, 0x2b, 0x80, 0x21, 0xda, 0x81, 0xc8, 0x84, 0xfb, 0xd9, 0x05, 0x54, 0xe1, 0xd8, 0x80, 0x5e,
-	0x64, 0x38, 0x5e, 0x63, 0x1c, 0x82, 0x21, 0xda, 0x6f, 0x56, 0xd8, 0x7f, 0xd7, 0xdc, 0x50, 0x0d,
-	0x49, 0xce, 0x9e, 0x51, 0x66, 0xe3, 0xca, 0x4e, 0x7b, 0x07, 0xdd, 0xd7, 0xe0, 0x35, 0x76, 0x0b,
-	0x67, 0x9b, 0xe2, 0xc1, 0x7a, 0x84, 0xf2, 0x3e, 0xb5, 0xff, 0x6f, 0x7a, 0x03, 0x01, 0x2d, 0x3f,
-	0x43, 0xcd, 0xda, 0xbb, 0x5b, 0x5c, 0xf6, 0x80, 0xae, 0x93, 0x16, 0xfd, 0x15, 0xdd, 0xfe, 0x09,
-	0x00, 0x00, 0xff, 0xff, 0x7f, 0xc1, 0xab, 0x86, 0x97, 0x04, 0x00, 0x00,
+	// 941 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x95, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
+	0x10, 0xad, 0x4c, 0xcb, 0x16, 0xc7, 0xb2, 0xec, 0x6e, 0x8c, 0x86, 0x30, 0x9a, 0x20, 0x58, 0x34,
+	0x40, 0x00, 0xa3, 0xae, 0xe1, 0xb4, 0xcd, 0xe5, 0xa9, 0x75, 0x8a, 0xc4, 0x45, 0x8a, 0x22, 0x65,
+	0x94, 0xbe, 0xf4, 0x89, 0xa2, 0x57, 0xd2, 0x22, 0x34, 0x29, 0x70, 0x97, 0x09, 0x9d, 0x9f, 0xe8,
+	0x6f, 0xe6, 0x2b, 0x82, 0xcc, 0xcc, 0x2e, 0x29, 0xca, 0x51, 0x7a, 0x79, 0x9b, 0xfb, 0x9c, 0x39,
+	0xb3, 0x43, 0x10, 0xc2, 0x34, 0xd3, 0xc7, 0x8b, 0xb2, 0xb0, 0x85, 0x08, 0xca, 0x45, 0x2a, 0x5f,
+	0x41, 0x38, 0x2e, 0x93, 0xdc, 0xfc, 0x9a, 0x4f, 0x0b, 0xf1, 0x15, 0x6c, 0x19, 0x95, 0xbe, 0x56,
+	0x57, 0x51, 0xef, 0x4e, 0xef, 0x5e, 0x18, 0x7b, 0x4d, 0x1c, 0x40, 0x3f, 0x2f, 0xf2, 0x54, 0x45,
+	0x1b, 0x68, 0x0e, 0x62, 0xa7, 0x88, 0x43, 0x18, 0xa4, 0x45, 0x6e, 0xcb, 0x24, 0xb5, 0x51, 0xc0,
+	0xf1, 0xad, 0x2e, 0x6f, 0xc1, 0x0e, 0x97, 0x45, 0x59, 0x17, 0xb9, 0x18, 0xc1, 0x86, 0xad, 0xb9,
+	0xe8, 0x30, 0x46, 0x49, 0x7e, 0x0f, 0xf0, 0xa2, 0x9a, 0x64, 0xda, 0xcc, 0x63, 0x65, 0x85, 0x80,
+	0xcd, 0xb4, 0xb8, 0x50, 0xec, 0xef, 0xc7, 0x2c, 0x93, 0x6d, 0x9e, 0x98, 0x39, 0x77, 0x1c, 0xc6,
+	0x2c, 0xcb, 0xdb, 0x30, 0x88, 0x95, 0x59, 0x14, 0xb9, 0x51, 0xeb, 0x72, 0xe4, 0x2f, 0x30, 0xea,
+	0x34, 0x7d, 0x8e, 0xc0, 0xbf, 0x86, 0x70, 0xe1, 0xfa, 0xa8, 0xd2, 0xb7, 0x5f, 0x1a, 0xd6, 0x8f,
+	0x25, 0xef, 0xc2, 0x5e, 0xa7, 0xca, 0x39, 0x36, 0x6e, 0xc1, 0xf4, 0x3a, 0x60, 0x8e, 0x20, 0xa0,
+	0x0e, 0x43, 0xe8, 0x19, 0xcf, 0x56, 0xcf, 0x10, 0x81, 0x73, 0xa5, 0x67, 0x73, 0xeb, 0x4b, 0x7a,
+	0x4d, 0xde, 0x84, 0xfe, 0x9f, 0x49, 0x56, 0x29, 0x22, 0xc2, 0xbc, 0x61, 0x67, 0x18, 0xa3, 0x24,
+	0xef, 0xc0, 0xe0, 0x2c, 0x2b, 0xd2, 0xd7, 0xcf, 0x1d, 0xcb, 0x59, 0x72, 0xe5, 0x81, 0x22, 0x1c,
+	0x56, 0xe4, 0xfb, 0x0d, 0xd8, 0x3c, 0x57, 0xc9, 0x85, 0x88, 0x60, 0xfb, 0x8d, 0x2a, 0x0d, 0x62,
+	0xf2, 0x01, 0x8d, 0x2a, 0x6e, 0x03, 0x2c, 0x92, 0x52, 0xe5, 0xf6, 0x7c, 0xc9, 0x58, 0xc7, 0x42,
+	0x8b, 0xb2, 0xa5, 0x52, 0xec, 0x0d, 0xd8, 0xdb, 0xea, 0xc4, 0xd0, 0x84, 0x00, 0xb0, 0x73, 0xd3,
+	0x31, 0xd4, 0x1a, 0x68, 0x70, 0x8d, 0x0f, 0x23, 0xea, 0xbb, 0xc1, 0xb5, 0x7f, 0x24, 0x79, 0x75,
+	0x39, 0x41, 0x9c, 0x5b, 0x6e, 0x46, 0xa7, 0x11, 0xbe, 0xb7, 0xda, 0xe6, 0xca, 0x98, 0x68, 0x9b,
+	0xe7, 0x6b, 0x54, 0xea, 0x61, 0xf4, 0x2c, 0x4f, 0x6c, 0x55, 0xaa, 0x68, 0xe0, 0x7a, 0xb4, 0x06,
+	0xea, 0x61, 0xf5, 0xa5, 0x8a, 0x42, 0xae, 0xc6, 0xb2, 0x90, 0x30, 0x2c, 0x55, 0xaa, 0xf4, 0xc2,
+	0x9a, 0xb8, 0x28, 0x6c, 0x04, 0x9c, 0xb4, 0x62, 0xe3, 0xaa, 0x36, 0xb1, 0x8a, 0x03, 0x76, 0x7c,
+	0xd5, 0xc6, 0x40, 0x68, 0x66, 0x89, 0x79, 0x65, 0xd4, 0x45, 0x34, 0x74, 0x6c, 0x79, 0x95, 0x3c,
+	0xb6, 0x7e, 0x52, 0x54, 0xb9, 0x8d, 0x76, 0x9d, 0xc7, 0xab, 0xf2, 0x12, 0x42, 0x5e, 0x06, 0xdf,
+	0xc2, 0x2d, 0xdc, 0x39, 0xd2, 0xce, 0x5c, 0xef, 0x9c, 0x86, 0xc7, 0x78, 0x2c, 0xc7, 0xb4, 0x87,
+	0x98, 0xcd, 0xb4, 0xac, 0x71, 0x9d, 0xe6, 0xcd, 0xa2, 0x9d, 0x22, 0x8e, 0x60, 0xcb, 0xd6, 0xbf,
+	0x69, 0x43, 0x07, 0x11, 0x60, 0xda, 0x0d, 0x4e, 0x5b, 0x7d, 0x94, 0xb1, 0x0f, 0x91, 0x7f, 0xf7,
+	0x60, 0x3b, 0x76, 0x13, 0x11, 0xa9, 0xb6, 0x3e, 0x5f, 0xbe, 0x31, 0xaf, 0xf1, 0x45, 0xe2, 0x4c,
+	0x95, 0xe1, 0x3e, 0xfd, 0xd8, 0x6b, 0xdd, 0xf1, 0x82, 0xd5, 0xf1, 0xf6, 0x21, 0x98, 0x2a, 0xc5,
+	0xab, 0x0c, 0x63, 0x12, 0x89, 0xe0, 0xac, 0x98, 0x19, 0x5c, 0x62, 0x80, 0x26, 0x96, 0x09, 0xbe,
+	0x2a, 0xcb, 0xc2, 0xed, 0x30, 0x8c, 0x9d, 0x22, 0x1f, 0xc0, 0x8e, 0x07, 0x44, 0x00, 0xc5, 0x3d,
+	0x18, 0x34, 0x8c, 0x23, 0x2c, 0x9a, 0x67, 0xc8, 0xf3, 0xf8, 0x98, 0xb8, 0xf5, 0xca, 0xef, 0xa0,
+	0xff, 0x3b, 0x7f, 0x13, 0xa8, 0x57, 0x82, 0xe3, 0xbb, 0x17, 0xca, 0x32, 0xd9, 0x72, 0x55, 0x37,
+	0x4c, 0xb1, 0x2c, 0xff, 0x82, 0xdd, 0x17, 0x2a, 0xbf, 0xd0, 0xf9, 0x6c, 0x5c, 0x9b, 0xb5, 0x97,
+	0x1a, 0x76, 0x2f, 0x15, 0x69, 0x28, 0xa6, 0x53, 0xa3, 0xda, 0xbb, 0x72, 0x1a, 0x9f, 0x8c, 0xbe,
+	0xd4, 0xd6, 0x93, 0xe0, 0x14, 0xf9, 0x14, 0xbf, 0x2e, 0x6d, 0x71, 0x8a, 0xb1, 0x85, 0x4d, 0xb2,
+	0xe6, 0xac, 0x58, 0xc1, 0x17, 0x16, 0xd8, 0x9a, 0x58, 0xa5, 0xb1, 0xf6, 0xaf, 0xaf, 0x29, 0x26,
+	0xa7, 0x7c, 0x07, 0xc3, 0x9f, 0xd3, 0x94, 0x9e, 0xc6, 0x1f, 0x95, 0xaa, 0xfe, 0xf3, 0x70, 0xd4,
+	0xb1, 0xc4, 0x37, 0x72, 0xd5, 0xa0, 0x62, 0x85, 0xac, 0x5c, 0x8b, 0x57, 0x83, 0x56, 0x56, 0xf8,
+	0x9a, 0x88, 0x39, 0xb7, 0x1e, 0xba, 0x26, 0xd6, 0x24, 0x72, 0x3f, 0xae, 0x9f, 0xea, 0xcc, 0xe2,
+	0xf4, 0xff, 0xc8, 0xcd, 0xe9, 0x87, 0x3e, 0x04, 0x4f, 0x32, 0x2d, 0x4e, 0x20, 0xf4, 0xdf, 0xd4,
+	0x71, 0x2d, 0x3e, 0x99, 0xe8, 0x70, 0x8f, 0x2d, 0xcb, 0xaf, 0xae, 0xfc, 0x42, 0x3c, 0x82, 0xd1,
+	0x33, 0x65, 0xbb, 0xdf, 0xe9, 0x75, 0xef, 0xf5, 0xf0, 0x93, 0x5a, 0x98, 0xfa, 0x13, 0x1c, 0xac,
+	0xa6, 0x9e, 0x5d, 0xf1, 0x7b, 0x3d, 0xb8, 0x1e, 0x4b, 0xd6, 0xb5, 0x15, 0xbe, 0x01, 0xc0, 0x0a,
+	0x67, 0x49, 0x96, 0xd0, 0xbb, 0x19, 0x70, 0x04, 0x75, 0x03, 0x96, 0xf8, 0x6b, 0x89, 0x51, 0x12,
+	0x06, 0x18, 0xf5, 0x92, 0xce, 0xfa, 0xb3, 0x31, 0x47, 0x1c, 0xc3, 0x97, 0x2b, 0x76, 0xd9, 0xd3,
+	0x7c, 0x52, 0x0f, 0x47, 0x4b, 0x95, 0x8e, 0x1a, 0x83, 0xef, 0xc3, 0x7e, 0x13, 0x8c, 0x90, 0xf9,
+	0xeb, 0xfc, 0xef, 0x49, 0xdf, 0xe2, 0x32, 0x08, 0xfc, 0x14, 0x97, 0x31, 0x5a, 0xce, 0x42, 0xde,
+	0x75, 0xbc, 0x9e, 0xf2, 0x68, 0xcd, 0x69, 0xaf, 0xa7, 0x64, 0xe5, 0x92, 0x30, 0xe7, 0x87, 0x25,
+	0x2e, 0x6f, 0x34, 0xd7, 0x71, 0xed, 0x77, 0x53, 0xf8, 0x0b, 0xd2, 0xf0, 0xe3, 0x6e, 0xef, 0x3a,
+	0x3f, 0x6c, 0xc5, 0x98, 0x1f, 0x61, 0x17, 0x63, 0x3a, 0x17, 0x21, 0x1c, 0xe4, 0xee, 0xfd, 0x35,
+	0x63, 0xb4, 0x36, 0xcc, 0x7b, 0x08, 0xc3, 0x6e, 0xde, 0xff, 0xd8, 0xed, 0x09, 0xec, 0x61, 0xe6,
+	0xca, 0xed, 0x2c, 0xc1, 0x7d, 0xc9, 0x52, 0xd7, 0x89, 0x19, 0x8f, 0xe1, 0xc6, 0xcb, 0x6a, 0x62,
+	0xd2, 0x52, 0x4f, 0x54, 0x07, 0xa9, 0x63, 0xa0, 0x39, 0x84, 0x75, 0xbd, 0x4e, 0x7a, 0x93, 0x2d,
+	0xfe, 0x9d, 0xb9, 0xff, 0x11, 0x10, 0xa0, 0x58, 0xe1, 0xdb, 0x08, 0x00, 0x00,
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/iost-official/Go-IOS-Protocol/common"
 	"github.com/iost-official/Go-IOS-Protocol/consensus"
 	"github.com/iost-official/Go-IOS-Protocol/core/block"
+	"github.com/iost-official/Go-IOS-Protocol/core/blockcache"
 	"github.com/iost-official/Go-IOS-Protocol/core/message"
 	"github.com/iost-official/Go-IOS-Protocol/core/state"
 	"github.com/iost-official/Go-IOS-Protocol/core/tx"
@@ -176,13 +177,17 @@ func (s *RpcServer) GetBalance(ctx context.Context, iak *Key) (*Value, error) {
 		return nil, fmt.Errorf("argument cannot be nil pointer")
 	}
 	ia := iak.S
-	val0, err := state.StdPool.GetHM("iost", state.Key(ia))
+	stPool, err := statePoolAt(iak.Height)
 	if err != nil {
 		return nil, err
 	}
-	val, ok := val0.(*state.VFloat)
+	val0, err := stPool.GetHM("iost", state.Key(ia))
+	if err != nil {
+		return nil, err
+	}
+	val, ok := val0.(*state.VDecimal)
 	if !ok {
-		return nil, fmt.Errorf("RPC : pool type error: should VFloat, acture %v; in iost.%v",
+		return nil, fmt.Errorf("RPC : pool type error: should VDecimal, acture %v; in iost.%v",
 			reflect.TypeOf(val0).String(), vm.IOSTAccount(ia))
 	}
 	balance := val.EncodeString()
@@ -197,9 +202,9 @@ func (s *RpcServer) GetState(ctx context.Context, stkey *Key) (*Value, error) {
 	}
 	key := stkey.S
 
-	stPool := state.StdPool
-	if stPool == nil {
-		panic(fmt.Errorf("state.StdPool shouldn't be nil"))
+	stPool, err := statePoolAt(stkey.Height)
+	if err != nil {
+		return nil, err
 	}
 	stValue, err := stPool.Get(state.Key(key))
 	if err != nil {
@@ -209,6 +214,51 @@ func (s *RpcServer) GetState(ctx context.Context, stkey *Key) (*Value, error) {
 	return &Value{Sv: stValue.EncodeString()}, nil
 }
 
+// GetNonce returns the last nonce used by account stkey.S and the nonce of its next tx,
+// in the longest chain when stkey.Height is 0
+func (s *RpcServer) GetNonce(ctx context.Context, stkey *Key) (*Nonce, error) {
+	if stkey == nil {
+		return nil, fmt.Errorf("argument cannot be nil pointer")
+	}
+	var stPool state.Pool
+	if stkey.Height == 0 {
+		stPool = longestPool()
+	} else {
+		var err error
+		stPool, err = statePoolAt(stkey.Height)
+		if err != nil {
+			return nil, err
+		}
+	}
+	last, err := blockcache.LastNonce(vm.IOSTAccount(stkey.S), stPool)
+	if err != nil {
+		return nil, err
+	}
+	return &Nonce{Last: last, Next: last + 1}, nil
+}
+
+// longestPool returns the state after the head of the longest chain, blocks not
+// confirmed yet included
+func longestPool() state.Pool {
+	Cons := consensus.Cons
+	if Cons == nil {
+		panic(fmt.Errorf("Consensus is nil"))
+	}
+	return Cons.CachedStatePool()
+}
+
+// statePoolAt returns the state after block height, or the latest state when height is 0
+func statePoolAt(height int64) (state.Pool, error) {
+	stPool := state.StdPool
+	if stPool == nil {
+		panic(fmt.Errorf("state.StdPool shouldn't be nil"))
+	}
+	if height == 0 {
+		return stPool, nil
+	}
+	return stPool.At(height)
+}
+
 func (s *RpcServer) GetBlock(ctx context.Context, bk *BlockKey) (*BlockInfo, error) {
 	if bk == nil {
 		return nil, fmt.Errorf("argument cannot be nil pointer")
@@ -229,15 +279,19 @@ func (s *RpcServer) GetBlock(ctx context.Context, bk *BlockKey) (*BlockInfo, err
 	}
 
 	head := &Head{
-		Version:    block.Head.Version,
-		ParentHash: block.Head.ParentHash,
-		TreeHash:   block.Head.TreeHash,
-		BlockHash:  block.HeadHash(),
-		Info:       block.Head.Info,
-		Number:     block.Head.Number,
-		Witness:    block.Head.Witness,
-		Signature:  block.Head.Signature,
-		Time:       block.Head.Time,
+		Version:      block.Head.Version,
+		ParentHash:   block.Head.ParentHash,
+		TreeHash:     block.Head.TreeHash,
+		BlockHash:    block.HeadHash(),
+		Info:         block.Head.Info,
+		Number:       block.Head.Number,
+		Witness:      block.Head.Witness,
+		Signature:    block.Head.Signature,
+		Time:         block.Head.Time,
+		ReceiptsRoot: block.Head.ReceiptsRoot,
+		StateRoot:    block.Head.StateRoot,
+		GasUsed:      block.Head.GasUsed,
+		TxCount:      block.Head.TxCount,
 	}
 
 	txList := make([]*TransactionKey, block.LenTx())
@@ -275,15 +329,19 @@ func (s *RpcServer) GetBlockByHeight(ctx context.Context, bk *BlockKey) (*BlockI
 	}
 
 	head := &Head{
-		Version:    block.Head.Version,
-		ParentHash: block.Head.ParentHash,
-		TreeHash:   block.Head.TreeHash,
-		BlockHash:  block.HeadHash(),
-		Info:       block.Head.Info,
-		Number:     block.Head.Number,
-		Witness:    block.Head.Witness,
-		Signature:  block.Head.Signature,
-		Time:       block.Head.Time,
+		Version:      block.Head.Version,
+		ParentHash:   block.Head.ParentHash,
+		TreeHash:     block.Head.TreeHash,
+		BlockHash:    block.HeadHash(),
+		Info:         block.Head.Info,
+		Number:       block.Head.Number,
+		Witness:      block.Head.Witness,
+		Signature:    block.Head.Signature,
+		Time:         block.Head.Time,
+		ReceiptsRoot: block.Head.ReceiptsRoot,
+		StateRoot:    block.Head.StateRoot,
+		GasUsed:      block.Head.GasUsed,
+		TxCount:      block.Head.TxCount,
 	}
 
 	txList := make([]*TransactionKey, block.LenTx())
@@ -300,3 +358,163 @@ func (s *RpcServer) GetBlockByHeight(ctx context.Context, bk *BlockKey) (*BlockI
 		TxList: txList,
 	}, nil
 }
+
+func receiptToRpc(r *block.Receipt) *Receipt {
+	fee := ""
+	if r.Fee != nil {
+		fee = r.Fee.String()
+	}
+	return &Receipt{
+		TxHash:  r.TxHash,
+		Status:  int32(r.Status),
+		GasUsed: int64(r.GasUsed),
+		Fee:     fee,
+		Logs:    r.Logs,
+		Error:   r.Error,
+	}
+}
+
+// GetReceipt returns the receipt of a tx in a confirmed block
+func (s *RpcServer) GetReceipt(ctx context.Context, hash *TransactionHash) (*Receipt, error) {
+	if hash == nil {
+		return nil, fmt.Errorf("argument cannot be nil pointer")
+	}
+
+	bc := block.BChain
+	if bc == nil {
+		panic(fmt.Errorf("block.BChain cannot be nil"))
+	}
+	r, err := bc.GetReceipt(hash.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Receipt: %v", err)
+	}
+	return receiptToRpc(r), nil
+}
+
+// GetBlockReceipts returns the receipts of the confirmed block at height bk.Layer
+func (s *RpcServer) GetBlockReceipts(ctx context.Context, bk *BlockKey) (*ReceiptList, error) {
+	if bk == nil {
+		return nil, fmt.Errorf("argument cannot be nil pointer")
+	}
+
+	bc := block.BChain
+	if bc == nil {
+		panic(fmt.Errorf("block.BChain cannot be nil"))
+	}
+	height := bk.Layer
+	curLen := bc.Length()
+	if (height < 0) || (uint64(height) > curLen-1) {
+		return nil, fmt.Errorf("out of bound")
+	}
+	hash := bc.GetHashByNumber(uint64(height))
+	if hash == nil {
+		return nil, fmt.Errorf("cannot get BlockInfo")
+	}
+	receipts, err := bc.GetReceipts(hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Receipts: %v", err)
+	}
+
+	list := make([]*Receipt, 0, len(receipts))
+	for _, r := range receipts {
+		list = append(list, receiptToRpc(r))
+	}
+	return &ReceiptList{Receipts: list}, nil
+}
+
+// defaultPendingPage and maxPendingPage bound the number of txs GetPendingTxs returns
+const (
+	defaultPendingPage = 100
+	maxPendingPage     = 1000
+)
+
+func txPoolServer() *txpool.TxPoolServer {
+	pool := txpool.TxPoolS
+	if pool == nil {
+		panic(fmt.Errorf("txpool.TxPoolS shouldn't be nil"))
+	}
+	return pool
+}
+
+// GetPendingTxs returns a page of the txs waiting in the pool, the best paying first
+func (s *RpcServer) GetPendingTxs(ctx context.Context, key *PendingTxsKey) (*PendingTxs, error) {
+	if key == nil {
+		return nil, fmt.Errorf("argument cannot be nil pointer")
+	}
+	if key.Offset < 0 || key.Limit < 0 {
+		return nil, fmt.Errorf("offset and limit cannot be negative")
+	}
+	limit := key.Limit
+	switch {
+	case limit == 0:
+		limit = defaultPendingPage
+	case limit > maxPendingPage:
+		limit = maxPendingPage
+	}
+
+	txs, total := txPoolServer().PendingList(vm.IOSTAccount(key.Publisher), int(key.Offset), int(limit))
+	list := make([]*Transaction, 0, len(txs))
+	for _, t := range txs {
+		list = append(list, &Transaction{Tx: t.Encode()})
+	}
+	return &PendingTxs{Total: int64(total), Txs: list}, nil
+}
+
+// GetPendingTx returns the tx with hash txhash.Hash waiting in the pool
+func (s *RpcServer) GetPendingTx(ctx context.Context, txhash *TransactionHash) (*Transaction, error) {
+	if txhash == nil {
+		return nil, fmt.Errorf("argument cannot be nil pointer")
+	}
+	t := txPoolServer().TransactionByHash(txhash.Hash)
+	if t == nil {
+		return nil, fmt.Errorf("tx not in pool")
+	}
+	return &Transaction{Tx: t.Encode()}, nil
+}
+
+// GetAccountQueue returns the state of the txs of account stkey.S in the pool
+func (s *RpcServer) GetAccountQueue(ctx context.Context, stkey *Key) (*AccountQueue, error) {
+	if stkey == nil {
+		return nil, fmt.Errorf("argument cannot be nil pointer")
+	}
+	qs, err := txPoolServer().AccountQueue(vm.IOSTAccount(stkey.S))
+	if err != nil {
+		return nil, err
+	}
+	return &AccountQueue{
+		Last:   qs.Last,
+		Next:   qs.Next,
+		Ready:  int64(qs.Ready),
+		Count:  int64(qs.Count),
+		Nonces: qs.Nonces,
+	}, nil
+}
+
+// SubscribePendingTxs streams the txs admitted to the pool from now on, of
+// filter.Publisher only when it is set, until the client goes away
+func (s *RpcServer) SubscribePendingTxs(filter *TxFilter, stream Cli_SubscribePendingTxsServer) error {
+	if filter == nil {
+		return fmt.Errorf("argument cannot be nil pointer")
+	}
+	pool := txPoolServer()
+	ch := pool.SubscribeTx()
+	defer pool.UnsubscribeTx(ch)
+
+	publisher := vm.IOSTAccount(filter.Publisher)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case t, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if publisher != "" && vm.PubkeyToIOSTAccount(t.Publisher.Pubkey) != publisher {
+				continue
+			}
+			if err := stream.Send(&Transaction{Tx: t.Encode()}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/iost-official/Go-IOS-Protocol/account"
+	"github.com/iost-official/Go-IOS-Protocol/consensus"
 	"github.com/iost-official/Go-IOS-Protocol/core/block"
 	"github.com/iost-official/Go-IOS-Protocol/core/mocks"
 	"github.com/iost-official/Go-IOS-Protocol/core/state"
@@ -19,6 +20,16 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+// longestConsensus serves pool as the state of the longest chain
+type longestConsensus struct {
+	consensus.Consensus
+	pool state.Pool
+}
+
+func (c *longestConsensus) CachedStatePool() state.Pool {
+	return c.pool
+}
+
 func TestRpcServer(t *testing.T) {
 	Convey("Test of RpcServer", t, func() {
 		txDb := tx.TxDbInstance()
@@ -61,6 +72,31 @@ func TestRpcServer(t *testing.T) {
 			_, err := hs.GetState(context.Background(), &Key{S: "HowHsu"})
 			So(err, ShouldBeNil)
 		})
+		Convey("Test of GetNonce", func() {
+			ctl := gomock.NewController(t)
+			mockPool := core_mock.NewMockPool(ctl)
+			mockPool.EXPECT().GetHM(state.Key("nonce"), state.Key("HowHsu")).Return(state.MakeVInt(7), nil)
+			mockPool.EXPECT().GetHM(state.Key("nonce"), state.Key("Nobody")).Return(state.VNil, nil)
+			mockPool.EXPECT().GetHM(state.Key("nonce"), state.Key("Broken")).Return(state.MakeVString("7"), nil)
+			// the flushed state lags behind the longest chain and must not be read
+			state.StdPool = core_mock.NewMockPool(ctl)
+			cons := consensus.Cons
+			consensus.Cons = &longestConsensus{pool: mockPool}
+			defer func() { consensus.Cons = cons }()
+
+			hs := new(RpcServer)
+			n, err := hs.GetNonce(context.Background(), &Key{S: "HowHsu"})
+			So(err, ShouldBeNil)
+			So(n.Last, ShouldEqual, 7)
+			So(n.Next, ShouldEqual, 8)
+
+			n, err = hs.GetNonce(context.Background(), &Key{S: "Nobody"})
+			So(err, ShouldBeNil)
+			So(n.Next, ShouldEqual, 0)
+
+			_, err = hs.GetNonce(context.Background(), &Key{S: "Broken"})
+			So(err, ShouldNotBeNil)
+		})
 		Convey("Test of GetBlock", func() {
 			ctl := gomock.NewController(t)
 			mockChain := core_mock.NewMockChain(ctl)
@@ -87,14 +123,14 @@ func TestRpcServer(t *testing.T) {
 		Convey("Test of GetBalance", func() {
 			ctl := gomock.NewController(t)
 			mockPool := core_mock.NewMockPool(ctl)
-			mockPool.EXPECT().GetHM(gomock.Any(), gomock.Any()).AnyTimes().Return(state.MakeVFloat(18.0), nil)
+			mockPool.EXPECT().GetHM(gomock.Any(), gomock.Any()).AnyTimes().Return(state.DecimalFromInt(18), nil)
 			state.StdPool = mockPool
 
 			hs := new(RpcServer)
 			balance, err := hs.GetBalance(context.Background(), &Key{S: "HowHsu"})
 			So(err, ShouldBeNil)
 
-			vf := state.MakeVFloat(18.0)
+			vf := state.DecimalFromInt(18)
 			So(balance.Sv, ShouldEqual, vf.EncodeString())
 
 		})
@@ -73,6 +73,16 @@ func (m *vmMonitor) startVM(contract vm.Contract) (vm.VM, error) {
 	}
 }
 
+// ParseContract checks that a vm can load contract, without running it
+func ParseContract(contract vm.Contract) error {
+	switch c := contract.(type) {
+	case *lua.Contract:
+		return c.Compile()
+	default:
+		return fmt.Errorf("contract not supported")
+	}
+}
+
 func (m *vmMonitor) RestartVM(contract vm.Contract) (vm.VM, error) {
 	if m.hotVM == nil || m.needRestartHotVM {
 		m.needRestartHotVM = false
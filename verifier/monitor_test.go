@@ -24,7 +24,7 @@ func TestContractCall(t *testing.T) {
 		pool := core_mock.NewMockPool(mockCtl)
 
 		pool.EXPECT().Copy().AnyTimes().Return(pool)
-		v3 := state.MakeVFloat(float64(10000))
+		v3 := state.DecimalFromInt(10000)
 		pool.EXPECT().GetHM(gomock.Any(), gomock.Any()).Return(v3, nil)
 
 		code1 := `function main()
@@ -67,7 +67,7 @@ end`
 		pool := core_mock.NewMockPool(mockCtl)
 
 		pool.EXPECT().Copy().AnyTimes().Return(pool)
-		v3 := state.MakeVFloat(float64(10000))
+		v3 := state.DecimalFromInt(10000)
 		pool.EXPECT().GetHM(gomock.Any(), gomock.Any()).Return(v3, nil)
 		pool.EXPECT().Get(gomock.Any()).Return(v3, nil)
 
@@ -209,8 +209,8 @@ end--f
 		//tmain := tx.NewTx(123, cmain)
 		//tcall := tx.NewTx(456, ccall)
 
-		pool.PutHM("iost", "publisher", state.MakeVFloat(10000))
-		pool.PutHM("iost", "caller", state.MakeVFloat(10000))
+		pool.PutHM("iost", "publisher", state.DecimalFromInt(10000))
+		pool.PutHM("iost", "caller", state.DecimalFromInt(10000))
 
 		verifier := CacheVerifier{
 			Verifier: Verifier{vmMonitor: newVMMonitor(), Context: vm.BaseContext()},
@@ -244,8 +244,8 @@ func TestContext(t *testing.T) {
 		mmdb := state.NewDatabase(mdb)
 		pool := state.NewPool(mmdb)
 
-		pool.PutHM("iost", "payer", state.MakeVFloat(10000))
-		pool.PutHM("iost", "receiver", state.MakeVFloat(10000))
+		pool.PutHM("iost", "payer", state.DecimalFromInt(10000))
+		pool.PutHM("iost", "receiver", state.DecimalFromInt(10000))
 
 		code1 := `function main()
 	return Call("con2", "pay", "payer")
@@ -274,6 +274,6 @@ end`
 		So(err, ShouldBeNil)
 		So(gas, ShouldEqual, 1013)
 		pb, _ := pool.GetHM("iost", "payer")
-		So(pb.(*state.VFloat).ToFloat64(), ShouldEqual, 9990)
+		So(pb.(*state.VDecimal).Float64(), ShouldEqual, 9990)
 	})
 }
@@ -17,10 +17,12 @@ import (
 )
 
 const (
-	MaxBlockGas uint64  = 1000000
-	TxBaseFee   float64 = 0.01
+	MaxBlockGas uint64 = 1000000
 )
 
+// TxBaseFee is charged on top of the gas of every transaction
+var TxBaseFee = state.MustParseDecimal("0.01")
+
 //go:generate gencode go -schema=structs.schema -package=verifier
 
 type Verifier struct {
@@ -41,23 +43,45 @@ type CacheVerifier struct {
 	Verifier
 }
 
-func balanceOfSender(sender vm.IOSTAccount, pool state.Pool) float64 {
+// balanceOfSender reads the balance of sender, a float balance left by a database
+// not migrated yet is read as the decimal MigrateDatabase would turn it into
+func balanceOfSender(sender vm.IOSTAccount, pool state.Pool) (*state.VDecimal, error) {
 	val0, err := pool.GetHM("iost", state.Key(sender))
 	if err != nil {
-		return 0
+		return state.DecimalFromInt(0), nil
+	}
+	switch val := val0.(type) {
+	case *state.VDecimal:
+		return val, nil
+	case *state.VFloat:
+		return state.DecimalFromFloat(val.ToFloat64()), nil
 	}
-	val, ok := val0.(*state.VFloat)
 	if val0 == state.VNil {
-		return 0
-	} else if !ok {
-		panic(fmt.Errorf("pool type error: should VFloat, acture %v; in iost.%v",
-			reflect.TypeOf(val0).String(), string(sender)))
+		return state.DecimalFromInt(0), nil
 	}
-	return val.ToFloat64()
+	return nil, fmt.Errorf("pool type error: should VDecimal, acture %v; in iost.%v",
+		reflect.TypeOf(val0).String(), string(sender))
 }
 
-func setBalanceOfSender(sender vm.IOSTAccount, pool state.Pool, amount float64) {
-	pool.PutHM("iost", state.Key(sender), state.MakeVFloat(amount))
+// BalanceOf returns the iost balance of account in pool
+func BalanceOf(account vm.IOSTAccount, pool state.Pool) (*state.VDecimal, error) {
+	return balanceOfSender(account, pool)
+}
+
+func setBalanceOfSender(sender vm.IOSTAccount, pool state.Pool, amount *state.VDecimal) {
+	pool.PutHM("iost", state.Key(sender), amount)
+}
+
+// GasFee returns the exact cost of gas at price, the price rounded to
+// state.DecimalPlaces fractional digits
+func GasFee(gas uint64, price float64) *state.VDecimal {
+	return state.DecimalFromFloat(price).MulUint64(gas)
+}
+
+// MaxFee returns the most a contract can charge its publisher, the fee of its whole
+// gas limit plus TxBaseFee
+func MaxFee(info vm.ContractInfo) *state.VDecimal {
+	return GasFee(uint64(info.GasLimit), info.Price).Add(TxBaseFee)
 }
 
 func (cv *CacheVerifier) VerifyContract(contract vm.Contract, pool state.Pool) (state.Pool, error) {
@@ -66,12 +90,15 @@ func (cv *CacheVerifier) VerifyContract(contract vm.Contract, pool state.Pool) (
 	}
 
 	sender := contract.Info().Publisher
-	bos := balanceOfSender(sender, pool)
-	if bos < float64(contract.Info().GasLimit)*contract.Info().Price+TxBaseFee {
-		return pool, fmt.Errorf("balance not enough: sender:%v balance:%f\n", string(sender), bos)
+	bos, err := balanceOfSender(sender, pool)
+	if err != nil {
+		return pool, err
+	}
+	if bos.Cmp(GasFee(uint64(contract.Info().GasLimit), contract.Info().Price).Add(TxBaseFee)) < 0 {
+		return pool, fmt.Errorf("balance not enough: sender:%v balance:%v\n", string(sender), bos.String())
 	}
 
-	_, err := cv.RestartVM(contract)
+	_, err = cv.RestartVM(contract)
 	if err != nil {
 		return pool, err
 	}
@@ -80,14 +107,17 @@ func (cv *CacheVerifier) VerifyContract(contract vm.Contract, pool state.Pool) (
 		return pool, err
 	}
 
-	bos2 := balanceOfSender(sender, pool)
+	bos2, err := balanceOfSender(sender, pool)
+	if err != nil {
+		return pool, err
+	}
 
 	if gas > uint64(contract.Info().GasLimit) {
 		return pool, errors.New("gas overflow")
 	}
 
-	bos2 -= float64(gas)*contract.Info().Price + TxBaseFee
-	if bos2 < 0 {
+	bos2 = bos2.Sub(GasFee(gas, contract.Info().Price).Add(TxBaseFee))
+	if bos2.Sign() < 0 {
 		return pool, fmt.Errorf("can not afford gas")
 	}
 
@@ -95,6 +125,83 @@ func (cv *CacheVerifier) VerifyContract(contract vm.Contract, pool state.Pool) (
 	return pool, nil
 }
 
+// Result is the outcome of a contract run by ExecuteContract
+type Result struct {
+	Gas  uint64
+	Fee  *state.VDecimal
+	Logs []string
+	Err  error
+}
+
+// ExecuteContract runs contract like VerifyContract, except that a contract which fails
+// once its fee is secured is still a valid transaction: its state changes are dropped,
+// the fee of the gas it used is charged and the failure is reported in Result.Err.
+// The returned error is only set when the transaction must not be included at all.
+func (cv *CacheVerifier) ExecuteContract(contract vm.Contract, pool state.Pool) (state.Pool, *Result, error) {
+	info := contract.Info()
+	if info.Price < 0 {
+		return pool, nil, errors.New("illegal gas price")
+	}
+
+	sender := info.Publisher
+	bos, err := balanceOfSender(sender, pool)
+	if err != nil {
+		return pool, nil, err
+	}
+	if bos.Cmp(GasFee(uint64(info.GasLimit), info.Price).Add(TxBaseFee)) < 0 {
+		return pool, nil, fmt.Errorf("balance not enough: sender:%v balance:%v\n", string(sender), bos.String())
+	}
+
+	_, err = cv.RestartVM(contract)
+	if err != nil {
+		return pool, nil, err
+	}
+
+	logs := make([]string, 0)
+	base := cv.Context
+	cv.Context = vm.NewContext(base)
+	cv.Context.Logs = &logs
+	defer func() {
+		cv.Context = base
+	}()
+
+	result := &Result{}
+	pool2, gas, err := cv.Verify(contract, pool.Copy())
+	if err == nil && gas > uint64(info.GasLimit) {
+		err = errors.New("gas overflow")
+	}
+	var bos2 *state.VDecimal
+	if err == nil {
+		bos2, err = balanceOfSender(sender, pool2)
+	}
+	if err == nil {
+		bos2 = bos2.Sub(GasFee(gas, info.Price).Add(TxBaseFee))
+		if bos2.Sign() < 0 {
+			err = fmt.Errorf("can not afford gas")
+		} else {
+			setBalanceOfSender(sender, pool2, bos2)
+			pool, err = pool2.MergeParent()
+			if err != nil {
+				return pool, nil, err
+			}
+			result.Gas = gas
+			result.Fee = GasFee(gas, info.Price).Add(TxBaseFee)
+			result.Logs = logs
+			return pool, result, nil
+		}
+	}
+
+	if gas > uint64(info.GasLimit) {
+		gas = uint64(info.GasLimit)
+	}
+	result.Gas = gas
+	result.Fee = GasFee(gas, info.Price).Add(TxBaseFee)
+	result.Logs = logs
+	result.Err = err
+	setBalanceOfSender(sender, pool, bos.Sub(result.Fee))
+	return pool, result, nil
+}
+
 func NewCacheVerifier() CacheVerifier {
 	cv := CacheVerifier{
 		Verifier: Verifier{
@@ -73,7 +73,7 @@ func TestCacheVerifier(t *testing.T) {
 				f2 = field
 				v2 = value
 			})
-			v3 := state.MakeVFloat(float64(1000000))
+			v3 := state.DecimalFromInt(1000000)
 			pool.EXPECT().GetHM(gomock.Any(), gomock.Any()).AnyTimes().Return(v3, nil)
 			pool.EXPECT().Copy().AnyTimes().Return(pool)
 			main := lua.NewMethod(vm.Public, "main", 0, 1)
@@ -91,8 +91,8 @@ end`
 			So(v.EncodeString(), ShouldEqual, "true")
 			So(string(k2), ShouldEqual, "iost")
 			So(string(f2), ShouldEqual, "ahaha")
-			vv := v2.(*state.VFloat)
-			So(vv.ToFloat64(), ShouldEqual, float64(997989.99))
+			vv := v2.(*state.VDecimal)
+			So(vv.Float64(), ShouldEqual, float64(997989.99))
 		})
 		Convey("Verify free contract", func() {
 			mockCtl := gomock.NewController(t)
@@ -117,8 +117,8 @@ end`
 				f2 = field
 				v2 = value
 			})
-			//v3 := state.MakeVFloat(float64(10000))
-			pool.EXPECT().GetHM(gomock.Any(), gomock.Any()).AnyTimes().Return(state.MakeVFloat(1000000), nil)
+			//v3 := state.DecimalFromInt(10000)
+			pool.EXPECT().GetHM(gomock.Any(), gomock.Any()).AnyTimes().Return(state.DecimalFromInt(1000000), nil)
 			pool.EXPECT().Copy().AnyTimes().Return(pool)
 			main := lua.NewMethod(vm.Public, "main", 0, 1)
 			code := `function main()
@@ -151,8 +151,8 @@ end`
 		}
 		sdb := state.NewDatabase(dbx)
 		pool := state.NewPool(sdb)
-		pool.PutHM(state.Key("iost"), state.Key("a"), state.MakeVFloat(1000000))
-		pool.PutHM(state.Key("iost"), state.Key("b"), state.MakeVFloat(1000000))
+		pool.PutHM(state.Key("iost"), state.Key("a"), state.DecimalFromInt(1000000))
+		pool.PutHM(state.Key("iost"), state.Key("b"), state.DecimalFromInt(1000000))
 		var pool2 state.Pool
 
 		cv := NewCacheVerifier()
@@ -161,8 +161,8 @@ end`
 		aa, err := pool2.GetHM("iost", "a")
 		ba, err := pool2.GetHM("iost", "b")
 		So(err, ShouldBeNil)
-		So(aa.(*state.VFloat).ToFloat64(), ShouldEqual, 999943.99)
-		So(ba.(*state.VFloat).ToFloat64(), ShouldEqual, 1000050)
+		So(aa.(*state.VDecimal).Float64(), ShouldEqual, 999943.99)
+		So(ba.(*state.VDecimal).Float64(), ShouldEqual, 1000050)
 	})
 
 }
@@ -181,8 +181,8 @@ end`
 		}
 		sdb := state.NewDatabase(dbx)
 		pool := state.NewPool(sdb)
-		pool.PutHM(state.Key("iost"), state.Key("a"), state.MakeVFloat(1000000))
-		pool.PutHM(state.Key("iost"), state.Key("b"), state.MakeVFloat(1000000))
+		pool.PutHM(state.Key("iost"), state.Key("a"), state.DecimalFromInt(1000000))
+		pool.PutHM(state.Key("iost"), state.Key("b"), state.DecimalFromInt(1000000))
 		var pool2 state.Pool
 
 		cv := NewCacheVerifier()
@@ -199,8 +199,8 @@ end`
 
 		aa2, err := pool3.GetHM("iost", "a")
 		So(err, ShouldBeNil)
-		So(aa2.(*state.VFloat).ToFloat64(), ShouldEqual, 999887.98)
-		So(ba.(*state.VFloat).ToFloat64(), ShouldEqual, 1000100)
+		So(aa2.(*state.VDecimal).Float64(), ShouldEqual, 999887.98)
+		So(ba.(*state.VDecimal).Float64(), ShouldEqual, 1000100)
 	})
 
 }
@@ -219,8 +219,8 @@ end`
 	}
 	sdb := state.NewDatabase(dbx)
 	pool := state.NewPool(sdb)
-	pool.PutHM(state.Key("iost"), state.Key("a"), state.MakeVFloat(1000000))
-	pool.PutHM(state.Key("iost"), state.Key("b"), state.MakeVFloat(1000000))
+	pool.PutHM(state.Key("iost"), state.Key("a"), state.DecimalFromInt(1000000))
+	pool.PutHM(state.Key("iost"), state.Key("b"), state.DecimalFromInt(1000000))
 
 	var pool2 state.Pool
 
@@ -250,8 +250,8 @@ end`
 	}
 	sdb := state.NewDatabase(dbx)
 	pool := state.NewPool(sdb)
-	pool.PutHM(state.Key("iost"), state.Key("a"), state.MakeVFloat(1000000))
-	pool.PutHM(state.Key("iost"), state.Key("b"), state.MakeVFloat(1000000))
+	pool.PutHM(state.Key("iost"), state.Key("a"), state.DecimalFromInt(1000000))
+	pool.PutHM(state.Key("iost"), state.Key("b"), state.DecimalFromInt(1000000))
 
 	var pool2 state.Pool
 
@@ -575,8 +575,8 @@ end--f
 	//tmain := tx.NewTx(123, cmain)
 	//tcall := tx.NewTx(456, ccall)
 
-	pool.PutHM("iost", "publisher", state.MakeVFloat(100000000))
-	pool.PutHM("iost", "caller", state.MakeVFloat(100000000))
+	pool.PutHM("iost", "publisher", state.DecimalFromInt(100000000))
+	pool.PutHM("iost", "caller", state.DecimalFromInt(100000000))
 
 	verifier := CacheVerifier{
 		Verifier: Verifier{vmMonitor: newVMMonitor(), Context: vm.BaseContext()},
@@ -599,3 +599,77 @@ end--f
 	}
 
 }
+
+func TestCacheVerifier_Execute(t *testing.T) {
+	Convey("Test of ExecuteContract", t, func() {
+		main := lua.NewMethod(vm.Public, "main", 0, 1)
+
+		dbx, err := db.DatabaseFactory("mem")
+		So(err, ShouldBeNil)
+		sdb := state.NewDatabase(dbx)
+		pool := state.NewPool(sdb)
+		pool.PutHM(state.Key("iost"), state.Key("a"), state.DecimalFromInt(1000000))
+		pool.PutHM(state.Key("iost"), state.Key("b"), state.DecimalFromInt(1000000))
+
+		cv := NewCacheVerifier()
+		cv.Context = vm.BaseContext()
+
+		Convey("success", func() {
+			code := `function main()
+	Log("paying b")
+	Transfer("a", "b", 50)
+end`
+			lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
+			pool2, res, err := cv.ExecuteContract(&lc, pool)
+			So(err, ShouldBeNil)
+			So(res.Err, ShouldBeNil)
+			So(res.Logs, ShouldResemble, []string{"paying b"})
+			ba, _ := pool2.GetHM("iost", "b")
+			So(ba.(*state.VDecimal).String(), ShouldEqual, state.DecimalFromInt(1000050).String())
+		})
+
+		Convey("failure keeps the fee only", func() {
+			code := `function main()
+	Transfer("a", "b", 50)
+	Assert(false)
+end`
+			lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("a")}, code, main)
+			pool2, res, err := cv.ExecuteContract(&lc, pool)
+			So(err, ShouldBeNil)
+			So(res.Err, ShouldNotBeNil)
+			aa, _ := pool2.GetHM("iost", "a")
+			ba, _ := pool2.GetHM("iost", "b")
+			So(ba.(*state.VDecimal).String(), ShouldEqual, state.DecimalFromInt(1000000).String())
+			So(aa.(*state.VDecimal).String(), ShouldEqual, state.DecimalFromInt(1000000).Sub(res.Fee).String())
+		})
+
+		Convey("unaffordable tx is rejected", func() {
+			lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 10000, Price: 1000, Publisher: vm.IOSTAccount("a")}, "function main() end", main)
+			_, _, err := cv.ExecuteContract(&lc, pool)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("legacy float balance is read as decimal", func() {
+			pool.PutHM(state.Key("iost"), state.Key("c"), state.MakeVFloat(100000.5))
+			pool.PutHM(state.Key("iost"), state.Key("d"), state.MakeVString("broken"))
+			bc, err := BalanceOf(vm.IOSTAccount("c"), pool)
+			So(err, ShouldBeNil)
+			So(bc.String(), ShouldEqual, state.DecimalFromFloat(100000.5).String())
+			_, err = BalanceOf(vm.IOSTAccount("d"), pool)
+			So(err, ShouldNotBeNil)
+
+			lc := lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("c")}, "function main() end", main)
+			pool2, res, err := cv.ExecuteContract(&lc, pool)
+			So(err, ShouldBeNil)
+			So(res.Err, ShouldBeNil)
+			ca, _ := pool2.GetHM("iost", "c")
+			So(ca.(*state.VDecimal).String(), ShouldEqual, state.DecimalFromFloat(100000.5).Sub(res.Fee).String())
+
+			lc = lua.NewContract(vm.ContractInfo{Prefix: "test", GasLimit: 10000, Price: 1, Publisher: vm.IOSTAccount("d")}, "function main() end", main)
+			_, _, err = cv.ExecuteContract(&lc, pool)
+			So(err, ShouldNotBeNil)
+			_, err = cv.VerifyContract(&lc, pool)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -8,6 +8,7 @@ type Context struct {
 	Timestamp   int64
 	BlockHeight int64
 	Witness     IOSTAccount
+	Logs        *[]string
 }
 
 func NewContext(ctx *Context) *Context {
@@ -19,3 +20,14 @@ func NewContext(ctx *Context) *Context {
 func BaseContext() *Context {
 	return &Context{Base: nil}
 }
+
+// Log records s in the nearest context that collects logs, the logs collected
+// while running a transaction are kept in its receipt
+func (c *Context) Log(s string) {
+	for ctx := c; ctx != nil; ctx = ctx.Base {
+		if ctx.Logs != nil {
+			*ctx.Logs = append(*ctx.Logs, s)
+			return
+		}
+	}
+}
@@ -36,6 +36,8 @@ func init() {
 
 var (
 	ErrBalanceNotEnough = errors.New("balance not enough")
+	ErrTypeMismatch     = errors.New("type mismatch")
+	ErrNegativeAmount   = errors.New("negative amount")
 )
 
 func Put(pool state.Pool, key state.Key, value state.Value) bool {
@@ -47,15 +49,196 @@ func Get(pool state.Pool, key state.Key) (state.Value, error) {
 	return pool.Get(key)
 }
 
+// ArrayNew puts an array of length elements, all nil, at key
+func ArrayNew(pool state.Pool, key state.Key, length int) error {
+	if length < 0 {
+		return state.ErrIndexOutOfRange
+	}
+	pool.Put(key, state.MakeVArray(length))
+	return nil
+}
+
+// ArrayGet returns the element i of the array at key, and the array length
+func ArrayGet(pool state.Pool, key state.Key, i int) (state.Value, int, error) {
+	arr, err := getArray(pool, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if i < 0 || i >= arr.Len() {
+		return nil, arr.Len(), state.ErrIndexOutOfRange
+	}
+	return arr.Get(i), arr.Len(), nil
+}
+
+// ArraySet sets the element i of the array at key, and returns the array length
+func ArraySet(pool state.Pool, key state.Key, i int, value state.Value) (int, error) {
+	arr, err := getArray(pool, key)
+	if err != nil {
+		return 0, err
+	}
+	arr = arr.Copy()
+	if err := arr.Set(i, value); err != nil {
+		return arr.Len(), err
+	}
+	pool.Put(key, arr)
+	return arr.Len(), nil
+}
+
+// StackPush pushes value on the stack at key, creating the stack when key is
+// not set, and returns the new stack length
+func StackPush(pool state.Pool, key state.Key, value state.Value) (int, error) {
+	st, err := getStack(pool, key)
+	if err != nil {
+		return 0, err
+	}
+	st = st.Copy()
+	st.Push(value)
+	pool.Put(key, st)
+	return st.Len(), nil
+}
+
+// StackPop pops the top of the stack at key, nil when it is empty, and
+// returns the stack length before the pop
+func StackPop(pool state.Pool, key state.Key) (state.Value, int, error) {
+	st, err := getStack(pool, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	l := st.Len()
+	if l == 0 {
+		return state.VNil, 0, nil
+	}
+	st = st.Copy()
+	v := st.Pop()
+	pool.Put(key, st)
+	return v, l, nil
+}
+
+// StackTop returns the top of the stack at key, nil when it is empty
+func StackTop(pool state.Pool, key state.Key) (state.Value, int, error) {
+	st, err := getStack(pool, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return st.Top(), st.Len(), nil
+}
+
+// QueuePush appends value to the queue at key, creating the queue when key is
+// not set, and returns the new queue length
+func QueuePush(pool state.Pool, key state.Key, value state.Value) (int, error) {
+	q, err := getQueue(pool, key)
+	if err != nil {
+		return 0, err
+	}
+	q = q.Copy()
+	q.Push(value)
+	pool.Put(key, q)
+	return q.Len(), nil
+}
+
+// QueuePop removes the front of the queue at key, nil when it is empty, and
+// returns the queue length before the pop
+func QueuePop(pool state.Pool, key state.Key) (state.Value, int, error) {
+	q, err := getQueue(pool, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	l := q.Len()
+	if l == 0 {
+		return state.VNil, 0, nil
+	}
+	q = q.Copy()
+	v := q.Pop()
+	pool.Put(key, q)
+	return v, l, nil
+}
+
+// QueueFront returns the front of the queue at key, nil when it is empty
+func QueueFront(pool state.Pool, key state.Key) (state.Value, int, error) {
+	q, err := getQueue(pool, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return q.Front(), q.Len(), nil
+}
+
+// Len returns the length of the array, stack or queue at key
+func Len(pool state.Pool, key state.Key) (int, error) {
+	v, err := pool.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	switch vv := v.(type) {
+	case *state.VArray:
+		return vv.Len(), nil
+	case *state.VStack:
+		return vv.Len(), nil
+	case *state.VQueue:
+		return vv.Len(), nil
+	case *state.VNilType:
+		return 0, nil
+	}
+	return 0, ErrTypeMismatch
+}
+
+func getArray(pool state.Pool, key state.Key) (*state.VArray, error) {
+	v, err := pool.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	arr, ok := v.(*state.VArray)
+	if !ok {
+		return nil, ErrTypeMismatch
+	}
+	return arr, nil
+}
+
+func getStack(pool state.Pool, key state.Key) (*state.VStack, error) {
+	v, err := pool.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	switch vv := v.(type) {
+	case *state.VStack:
+		return vv, nil
+	case *state.VNilType:
+		return state.MakeVStack(nil), nil
+	}
+	return nil, ErrTypeMismatch
+}
+
+func getQueue(pool state.Pool, key state.Key) (*state.VQueue, error) {
+	v, err := pool.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	switch vv := v.(type) {
+	case *state.VQueue:
+		return vv, nil
+	case *state.VNilType:
+		return state.MakeVQueue(nil), nil
+	}
+	return nil, ErrTypeMismatch
+}
+
 func Log(s, cid string) {
 	str := fmt.Sprintf("%v %v/%v: %v", time.Now().Format("2006-01-02 15:04:05.000"), "lua", cid, s)
 	logFile.Write([]byte(str))
 	logFile.Write([]byte("\n"))
 }
 
-func Transfer(pool state.Pool, src, des string, value float64) bool {
+// LogEvent writes s to vm.log like Log and records it in ctx for the receipt of the running tx
+func LogEvent(ctx *vm.Context, s, cid string) {
+	Log(s, cid)
+	ctx.Log(s)
+}
+
+func Transfer(pool state.Pool, src, des string, value *state.VDecimal) bool {
+	if value.Sign() < 0 {
+		return false
+	}
 
-	err := changeToken(pool, "iost", state.Key(src), -value)
+	err := changeToken(pool, "iost", state.Key(src), value.Neg())
 
 	if err != nil {
 		return false
@@ -69,8 +252,11 @@ func Transfer(pool state.Pool, src, des string, value float64) bool {
 	return true
 }
 
-func Deposit(pool state.Pool, contractPrefix, payer string, value float64) bool {
-	err := changeToken(pool, "iost", state.Key(payer), -value)
+func Deposit(pool state.Pool, contractPrefix, payer string, value *state.VDecimal) bool {
+	if value.Sign() < 0 {
+		return false
+	}
+	err := changeToken(pool, "iost", state.Key(payer), value.Neg())
 	if err != nil {
 		return false
 	}
@@ -84,8 +270,11 @@ func Deposit(pool state.Pool, contractPrefix, payer string, value float64) bool
 
 }
 
-func Withdraw(pool state.Pool, contractPrefix, payer string, value float64) bool {
-	err := changeToken(pool, "iost-contract", state.Key(contractPrefix), -value)
+func Withdraw(pool state.Pool, contractPrefix, payer string, value *state.VDecimal) bool {
+	if value.Sign() < 0 {
+		return false
+	}
+	err := changeToken(pool, "iost-contract", state.Key(contractPrefix), value.Neg())
 	if err != nil {
 		return false
 	}
@@ -234,23 +423,28 @@ func setTable(lt *lua.LTable, k string, v lua.LValue) {
 	}
 }
 
-func changeToken(pool state.Pool, key, field state.Key, delta float64) error {
+func changeToken(pool state.Pool, key, field state.Key, delta *state.VDecimal) error {
 	val0, err := pool.GetHM(state.Key(key), state.Key(field))
 	if err != nil {
 		return err
 	}
-	var val float64
+	var val *state.VDecimal
 	switch val0.(type) {
+	case *state.VDecimal:
+		val = val0.(*state.VDecimal)
 	case *state.VFloat:
-		val = val0.(*state.VFloat).ToFloat64()
+		// a float balance of a database not migrated yet, read like balanceOfSender does
+		val = state.DecimalFromFloat(val0.(*state.VFloat).ToFloat64())
+	case *state.VNilType:
+		val = state.DecimalFromInt(0)
 	default:
-		val = 0
+		return ErrTypeMismatch
 	}
 
-	if val+delta < 0 {
+	ba := val.Add(delta)
+	if ba.Sign() < 0 {
 		return ErrBalanceNotEnough
 	}
-	ba := state.MakeVFloat(val + delta)
 
 	pool.PutHM(state.Key(key), state.Key(field), ba)
 	return nil
@@ -13,14 +13,60 @@ func TestTransfer(t *testing.T) {
 		db, _ := db.DatabaseFactory("redis")
 		mdb := state.NewDatabase(db)
 		pool := state.NewPool(mdb)
-		pool.PutHM("iost", "a", state.MakeVFloat(100))
-		pool.PutHM("iost", "b", state.MakeVFloat(100))
+		pool.PutHM("iost", "a", state.DecimalFromInt(100))
+		pool.PutHM("iost", "b", state.DecimalFromInt(100))
 
-		Transfer(pool, "a", "b", 20)
+		Transfer(pool, "a", "b", state.DecimalFromInt(20))
 		aa, _ := pool.GetHM("iost", "a")
-		So(aa.(*state.VFloat).ToFloat64(), ShouldEqual, 80)
+		So(aa.(*state.VDecimal).Float64(), ShouldEqual, 80)
 		bb, _ := pool.GetHM("iost", "b")
-		So(bb.(*state.VFloat).ToFloat64(), ShouldEqual, 120)
+		So(bb.(*state.VDecimal).Float64(), ShouldEqual, 120)
 
+		So(Transfer(pool, "a", "b", state.DecimalFromInt(81)), ShouldBeFalse)
+		So(Transfer(pool, "a", "b", state.DecimalFromInt(-1)), ShouldBeFalse)
+		So(Transfer(pool, "a", "b", state.MustParseDecimal("0.1")), ShouldBeTrue)
+		aa, _ = pool.GetHM("iost", "a")
+		So(aa.(*state.VDecimal).String(), ShouldEqual, "79.9")
+
+		pool.PutHM("iost", "c", state.MakeVFloat(10.5))
+		So(Transfer(pool, "c", "b", state.MustParseDecimal("0.5")), ShouldBeTrue)
+		cc, _ := pool.GetHM("iost", "c")
+		So(cc.(*state.VDecimal).String(), ShouldEqual, "10")
+
+	})
+}
+
+func TestCollections(t *testing.T) {
+	Convey("Test of array, stack and queue apis", t, func() {
+		mdb, _ := db.NewMemDatabase()
+		pool := state.NewPool(state.NewDatabase(mdb))
+
+		So(ArrayNew(pool, "arr", 2), ShouldBeNil)
+		n, err := ArraySet(pool, "arr", 1, state.MakeVInt(3))
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 2)
+		_, err = ArraySet(pool, "arr", 2, state.MakeVInt(3))
+		So(err, ShouldEqual, state.ErrIndexOutOfRange)
+		v, _, err := ArrayGet(pool, "arr", 1)
+		So(err, ShouldBeNil)
+		So(v.EncodeString(), ShouldEqual, "i3")
+
+		StackPush(pool, "st", state.MakeVInt(1))
+		StackPush(pool, "st", state.MakeVInt(2))
+		v, n, err = StackPop(pool, "st")
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 2)
+		So(v.EncodeString(), ShouldEqual, "i2")
+
+		QueuePush(pool, "q", state.MakeVInt(1))
+		QueuePush(pool, "q", state.MakeVInt(2))
+		v, _, _ = QueuePop(pool, "q")
+		So(v.EncodeString(), ShouldEqual, "i1")
+		l, err := Len(pool, "q")
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, 1)
+
+		_, err = StackPush(pool, "q", state.MakeVInt(1))
+		So(err, ShouldEqual, ErrTypeMismatch)
 	})
 }
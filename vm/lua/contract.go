@@ -8,6 +8,7 @@ import (
 	"github.com/iost-official/Go-IOS-Protocol/common"
 	"github.com/iost-official/Go-IOS-Protocol/log"
 	"github.com/iost-official/Go-IOS-Protocol/vm"
+	"github.com/iost-official/gopher-lua"
 )
 
 // contract implement of lua contract
@@ -43,6 +44,13 @@ func (c *Contract) API(apiName string) (vm.Method, error) {
 func (c *Contract) Code() string {
 	return c.code
 }
+// Compile checks that the code of the contract is valid lua, it runs none of it
+func (c *Contract) Compile() error {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer L.Close()
+	_, err := L.LoadString(c.code)
+	return err
+}
 func (c *Contract) Encode() []byte {
 	cr := contractRaw{
 		info: c.info.Encode(),
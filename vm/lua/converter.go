@@ -117,10 +117,44 @@ func Core2Lua(value state.Value) (lua.LValue, error) {
 		return &vt, nil
 	case *state.VDeleteType:
 		return lua.LNil, nil
+	case *state.VDecimal:
+		return lua.LNumber(value.(*state.VDecimal).Float64()), nil
+	case *state.VArray:
+		return list2Lua(value.(*state.VArray).Values())
+	case *state.VStack:
+		return list2Lua(value.(*state.VStack).Values())
+	case *state.VQueue:
+		return list2Lua(value.(*state.VQueue).Values())
 	}
 	panic(fmt.Errorf("not support convertion: %v", reflect.TypeOf(value).String()))
 }
 
+// Lua2Decimal converts a token amount, a number rounded to
+// state.DecimalPlaces fractional digits or an exact decimal string
+func Lua2Decimal(value lua.LValue) (*state.VDecimal, error) {
+	switch value.(type) {
+	case lua.LNumber:
+		return state.DecimalFromFloat(float64(value.(lua.LNumber))), nil
+	case lua.LString:
+		return state.ParseDecimal(string(value.(lua.LString)))
+	}
+	return nil, fmt.Errorf("not an amount: %v", value.Type().String())
+}
+
+// list2Lua converts the elements of an array, stack or queue to a lua
+// sequence, indexed from 1
+func list2Lua(vals []state.Value) (lua.LValue, error) {
+	vt := lua.LTable{}
+	for i, val := range vals {
+		lv, err := Core2Lua(val)
+		if err != nil {
+			return nil, err
+		}
+		vt.RawSetInt(i+1, lv)
+	}
+	return &vt, nil
+}
+
 func Bool2Lua(b bool) lua.LValue {
 	var rtnl lua.LValue
 	if b {
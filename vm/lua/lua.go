@@ -161,7 +161,7 @@ func (l *VM) Prepare(monitor vm.Monitor) error {
 		name: "Log",
 		function: func(L *lua.LState) int {
 			k := L.ToString(1)
-			host.Log(k, l.contract.info.Prefix)
+			host.LogEvent(l.ctx, k, l.contract.info.Prefix)
 			return 0
 		},
 	}
@@ -190,6 +190,122 @@ func (l *VM) Prepare(monitor vm.Monitor) error {
 	}
 	l.APIs = append(l.APIs, Get)
 
+	var ArrayNew = api{
+		name: "ArrayNew",
+		function: func(L *lua.LState) int {
+			key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+			n := L.ToInt(2)
+			L.PCount += collectionGas(n)
+			if err := host.ArrayNew(l.cachePool, key, n); err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			L.Push(lua.LTrue)
+			return 1
+		},
+	}
+	l.APIs = append(l.APIs, ArrayNew)
+
+	var ArrayGet = api{
+		name: "ArrayGet",
+		function: func(L *lua.LState) int {
+			key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+			v, n, err := host.ArrayGet(l.cachePool, key, L.ToInt(2)-1)
+			L.PCount += collectionGas(n)
+			return pushValue(L, v, err)
+		},
+	}
+	l.APIs = append(l.APIs, ArrayGet)
+
+	var ArraySet = api{
+		name: "ArraySet",
+		function: func(L *lua.LState) int {
+			key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+			v, err := Lua2Core(L.Get(3))
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			n, err := host.ArraySet(l.cachePool, key, L.ToInt(2)-1, v)
+			L.PCount += collectionGas(n)
+			L.Push(Bool2Lua(err == nil))
+			return 1
+		},
+	}
+	l.APIs = append(l.APIs, ArraySet)
+
+	var StackPush = api{
+		name: "StackPush",
+		function: func(L *lua.LState) int {
+			key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+			v, err := Lua2Core(L.Get(2))
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			n, err := host.StackPush(l.cachePool, key, v)
+			L.PCount += collectionGas(n)
+			L.Push(Bool2Lua(err == nil))
+			return 1
+		},
+	}
+	l.APIs = append(l.APIs, StackPush)
+
+	var QueuePush = api{
+		name: "QueuePush",
+		function: func(L *lua.LState) int {
+			key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+			v, err := Lua2Core(L.Get(2))
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			n, err := host.QueuePush(l.cachePool, key, v)
+			L.PCount += collectionGas(n)
+			L.Push(Bool2Lua(err == nil))
+			return 1
+		},
+	}
+	l.APIs = append(l.APIs, QueuePush)
+
+	for _, op := range []struct {
+		name string
+		f    func(state.Pool, state.Key) (state.Value, int, error)
+	}{
+		{"StackPop", host.StackPop},
+		{"StackTop", host.StackTop},
+		{"QueuePop", host.QueuePop},
+		{"QueueFront", host.QueueFront},
+	} {
+		f := op.f
+		l.APIs = append(l.APIs, api{
+			name: op.name,
+			function: func(L *lua.LState) int {
+				key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+				v, n, err := f(l.cachePool, key)
+				L.PCount += collectionGas(n)
+				return pushValue(L, v, err)
+			},
+		})
+	}
+
+	var Len = api{
+		name: "Len",
+		function: func(L *lua.LState) int {
+			key := state.Key(l.contract.Info().Prefix + L.ToString(1))
+			n, err := host.Len(l.cachePool, key)
+			L.PCount += 1000
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			L.Push(lua.LTrue)
+			L.Push(lua.LNumber(n))
+			return 2
+		},
+	}
+	l.APIs = append(l.APIs, Len)
+
 	var Transfer = api{
 		name: "Transfer",
 		function: func(L *lua.LState) int {
@@ -199,8 +315,12 @@ func (l *VM) Prepare(monitor vm.Monitor) error {
 				return 1
 			}
 			des := L.ToString(2)
-			value := L.ToNumber(3)
-			rtn := host.Transfer(l.cachePool, src, des, float64(value))
+			value, err := Lua2Decimal(L.Get(3))
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			rtn := host.Transfer(l.cachePool, src, des, value)
 			L.Push(Bool2Lua(rtn))
 			return 1
 		},
@@ -215,8 +335,12 @@ func (l *VM) Prepare(monitor vm.Monitor) error {
 				L.Push(lua.LString("privilege error"))
 				return 1
 			}
-			value := L.ToNumber(2)
-			rtn := host.Deposit(l.cachePool, l.contract.Info().Prefix, src, float64(value))
+			value, err := Lua2Decimal(L.Get(2))
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			rtn := host.Deposit(l.cachePool, l.contract.Info().Prefix, src, value)
 			L.Push(Bool2Lua(rtn))
 			return 1
 		},
@@ -237,8 +361,12 @@ func (l *VM) Prepare(monitor vm.Monitor) error {
 		name: "Withdraw",
 		function: func(L *lua.LState) int {
 			des := L.ToString(1)
-			value := L.ToNumber(2)
-			rtn := host.Withdraw(l.cachePool, l.contract.Info().Prefix, des, float64(value))
+			value, err := Lua2Decimal(L.Get(2))
+			if err != nil {
+				L.Push(lua.LFalse)
+				return 1
+			}
+			rtn := host.Withdraw(l.cachePool, l.contract.Info().Prefix, des, value)
 			L.Push(Bool2Lua(rtn))
 			return 1
 		},
@@ -429,3 +557,25 @@ func (l *VM) Restart(contract vm.Contract) error {
 func (l *VM) Contract() vm.Contract {
 	return l.contract
 }
+
+// collectionGas is the cost of an array, stack or queue operation on a
+// collection of n elements
+func collectionGas(n int) uint64 {
+	return 1000 + 10*uint64(n)
+}
+
+// pushValue returns true and v to lua, or false when err is set
+func pushValue(L *lua.LState, v state.Value, err error) int {
+	if err != nil {
+		L.Push(lua.LFalse)
+		return 1
+	}
+	lv, err := Core2Lua(v)
+	if err != nil {
+		L.Push(lua.LFalse)
+		return 1
+	}
+	L.Push(lua.LTrue)
+	L.Push(lv)
+	return 2
+}
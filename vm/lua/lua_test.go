@@ -56,8 +56,8 @@ end`,
 			sdb := state.NewDatabase(db)
 			pool := state.NewPool(sdb)
 
-			pool.PutHM("iost", "a", state.MakeVFloat(100000))
-			pool.PutHM("iost", "b", state.MakeVFloat(100000))
+			pool.PutHM("iost", "a", state.DecimalFromInt(100000))
+			pool.PutHM("iost", "b", state.DecimalFromInt(100000))
 
 			main := NewMethod(vm.Public, "main", 0, 1)
 			lc := Contract{
@@ -88,8 +88,8 @@ end`,
 			sdb := state.NewDatabase(db)
 			pool := state.NewPool(sdb)
 
-			pool.PutHM("iost", "a", state.MakeVFloat(10))
-			pool.PutHM("iost", "b", state.MakeVFloat(100))
+			pool.PutHM("iost", "a", state.DecimalFromInt(10))
+			pool.PutHM("iost", "b", state.DecimalFromInt(100))
 
 			main := NewMethod(vm.Public, "main", 0, 0)
 			lc := Contract{
@@ -117,8 +117,8 @@ end`,
 			sdb := state.NewDatabase(db)
 			pool := state.NewPool(sdb)
 
-			pool.PutHM("iost", "a", state.MakeVFloat(10))
-			pool.PutHM("iost", "b", state.MakeVFloat(100))
+			pool.PutHM("iost", "a", state.DecimalFromInt(10))
+			pool.PutHM("iost", "b", state.DecimalFromInt(100))
 
 			main := NewMethod(vm.Public, "main", 0, 0)
 			lc := Contract{
@@ -207,8 +207,8 @@ end`,
 		}
 		sdb := state.NewDatabase(db)
 		pool := state.NewPool(sdb)
-		pool.PutHM("iost", "a", state.MakeVFloat(5000))
-		pool.PutHM("iost", "b", state.MakeVFloat(1000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(5000))
+		pool.PutHM("iost", "b", state.DecimalFromInt(1000))
 
 		lvm.Prepare(nil)
 		lvm.Start(&lc)
@@ -218,8 +218,8 @@ end`,
 		ab, err := pool.GetHM("iost", "a")
 		bb, err := pool.GetHM("iost", "b")
 		So(err, ShouldBeNil)
-		So(ab.(*state.VFloat).ToFloat64(), ShouldEqual, 4950)
-		So(bb.(*state.VFloat).ToFloat64(), ShouldEqual, 1050)
+		So(ab.(*state.VDecimal).Float64(), ShouldEqual, 4950)
+		So(bb.(*state.VDecimal).Float64(), ShouldEqual, 1050)
 
 	})
 }
@@ -353,8 +353,8 @@ end`,
 	}
 	sdb := state.NewDatabase(db)
 	pool := state.NewPool(sdb)
-	pool.PutHM("iost", "a", state.MakeVFloat(5000))
-	pool.PutHM("iost", "b", state.MakeVFloat(50))
+	pool.PutHM("iost", "a", state.DecimalFromInt(5000))
+	pool.PutHM("iost", "b", state.DecimalFromInt(50))
 
 	for i := 0; i < b.N; i++ {
 		lvm.Prepare(nil)
@@ -408,6 +408,10 @@ end`,
 		So(err, ShouldBeNil)
 		So(lc2.info.GasLimit, ShouldEqual, lc.info.GasLimit)
 		So(lc2.Code(), ShouldEqual, lc.code)
+		So(lc.Compile(), ShouldBeNil)
+
+		lc.code = `function main(`
+		So(lc.Compile(), ShouldNotBeNil)
 	})
 }
 
@@ -556,8 +560,8 @@ end`,
 		}
 		sdb := state.NewDatabase(db)
 		pool := state.NewPool(sdb)
-		pool.PutHM("iost", "a", state.MakeVFloat(5000))
-		pool.PutHM("iost", "b", state.MakeVFloat(1000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(5000))
+		pool.PutHM("iost", "b", state.DecimalFromInt(1000))
 
 		lvm.Prepare(nil)
 		lvm.Start(&lc)
@@ -572,8 +576,8 @@ end`,
 		ab, err := pool.GetHM("iost", "a")
 		bb, err := pool.GetHM("iost", "b")
 		So(err, ShouldBeNil)
-		So(ab.(*state.VFloat).ToFloat64(), ShouldEqual, 4950)
-		So(bb.(*state.VFloat).ToFloat64(), ShouldEqual, 1050)
+		So(ab.(*state.VDecimal).Float64(), ShouldEqual, 4950)
+		So(bb.(*state.VDecimal).Float64(), ShouldEqual, 1050)
 
 	})
 }
@@ -586,8 +590,8 @@ func TestVM_Restart(t *testing.T) {
 		}
 		sdb := state.NewDatabase(db)
 		pool := state.NewPool(sdb)
-		pool.PutHM("iost", "a", state.MakeVFloat(5000))
-		pool.PutHM("iost", "b", state.MakeVFloat(1000))
+		pool.PutHM("iost", "a", state.DecimalFromInt(5000))
+		pool.PutHM("iost", "b", state.DecimalFromInt(1000))
 
 		main := NewMethod(vm.Public, "main", 0, 1)
 		lc := Contract{